@@ -0,0 +1,54 @@
+package base
+
+import "github.com/omar0ali/spaceinvaders-game-cli/game/design"
+
+// Animator plays the animations of a design, it restarts when the animation changes.
+// Every animation loops except the death animation, which stops on its last frame.
+type Animator struct {
+	name    string
+	frame   int
+	elapsed float64 // seconds on the frame
+	ended   bool
+}
+
+func (a *Animator) Animate(d *design.Design, name string, delta float64) {
+	if name != a.name {
+		*a = Animator{name: name}
+	}
+	frames := d.Animations[name]
+	if len(frames) == 0 || a.ended {
+		return
+	}
+	a.elapsed += delta
+	for {
+		duration := float64(max(frames[a.frame].Duration, 1)) / 1000
+		if a.elapsed < duration {
+			return
+		}
+		a.elapsed -= duration
+		switch {
+		case a.frame < len(frames)-1:
+			a.frame++
+		case name == design.AnimationDeath:
+			a.ended = true
+			return
+		default:
+			a.frame = 0
+		}
+	}
+}
+
+// Ended reports whether the death animation played to the end, always true for designs
+// without one.
+func (a *Animator) Ended(d *design.Design) bool {
+	return a.ended || len(d.Animations[design.AnimationDeath]) == 0
+}
+
+// Sprite is the current frame, or the shape of the design when it has no frames.
+func (a *Animator) Sprite(d *design.Design) Sprite {
+	frames := d.Animations[a.name]
+	if a.frame < len(frames) {
+		return d.WithFrame(frames[a.frame])
+	}
+	return d
+}
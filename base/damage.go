@@ -0,0 +1,107 @@
+package base
+
+import (
+	"math"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/omar0ali/spaceinvaders-game-cli/game/design"
+)
+
+type Damage struct {
+	Amount int
+	Type   design.DamageType
+}
+
+func CollisionDamage(amount int) Damage {
+	return Damage{Amount: amount, Type: design.Collision}
+}
+
+// Shield is a regenerating layer on top of the health, it takes the damage first.
+type Shield struct {
+	Shield    int
+	MaxShield int
+
+	regen      float64 // points per second
+	regenDelay float64 // seconds without a hit before it regenerates
+	regenCarry float64 // keeps the fraction of regenerated points between frames
+	sinceHit   float64 // seconds of game time, stops while the game is paused
+}
+
+func (s *Shield) GetCurrent() int {
+	return s.Shield
+}
+
+func (s *Shield) GetMax() int {
+	return s.MaxShield
+}
+
+func (s *Shield) HasShield() bool {
+	return s.MaxShield > 0
+}
+
+func (s *Shield) IsShieldUp() bool {
+	return s.Shield > 0
+}
+
+// RegenerateShield should be called on every update of the entity.
+func (s *Shield) RegenerateShield(delta float64) {
+	if s.Shield >= s.MaxShield || s.regen <= 0 {
+		return
+	}
+	s.sinceHit += delta
+	if s.sinceHit < s.regenDelay {
+		return
+	}
+	s.regenCarry += s.regen * delta
+	points := int(s.regenCarry)
+	s.regenCarry -= float64(points)
+	s.Shield = min(s.Shield+points, s.MaxShield)
+}
+
+func (f *ObjectBase) SetDefense(d design.Defense) {
+	f.Armor = d.Armor
+	f.Resistances = d.Resistances
+	f.Shield = Shield{
+		Shield:     d.Shield,
+		MaxShield:  d.Shield,
+		regen:      d.ShieldRegen,
+		regenDelay: float64(d.ShieldRegenDelay) / 1000,
+		sinceHit:   float64(d.ShieldRegenDelay) / 1000, // regenerates from the start
+	}
+}
+
+// TakeDamage applies the resistance of the damage type, the shield absorbs what it can
+// and the armor reduces what reaches the health. Returns true when the shield took the hit.
+func (f *ObjectBase) TakeDamage(d Damage) bool {
+	resistance := min(f.Resistances[d.Type], 1)
+	amount := int(math.Ceil(float64(d.Amount) * (1 - resistance)))
+	f.Shield.sinceHit = 0
+
+	absorbed := false
+	if f.Shield.Shield > 0 && amount > 0 {
+		taken := min(f.Shield.Shield, amount)
+		f.Shield.Shield -= taken
+		amount -= taken
+		absorbed = true
+	}
+
+	if amount <= 0 {
+		return absorbed
+	}
+
+	f.Health -= max(amount-f.Armor, 1) // armor can't fully block a hit
+	return absorbed
+}
+
+// DisplayShield draws the shield bar, only if the entity has a shield.
+func (f *ObjectBase) DisplayShield(x, y, barSize int) {
+	if !f.HasShield() {
+		return
+	}
+	DisplayBar(
+		&f.Shield,
+		WithPosition(x, y),
+		WithBarSize(barSize),
+		WithStyle(StyleIt(tcell.ColorDeepSkyBlue)),
+	)
+}
@@ -13,11 +13,7 @@ type DropDown struct {
 
 func DeployDropDown(design design.Designable, level int) *DropDown {
 	w, _ := GetSize()
-
-	const padding = 30
-
-	distance := (w - (padding * 2))
-	xPos := rand.Intn(distance) + padding
+	xPos := SpawnX(w)
 
 	width := len(design.GetShape()[0])
 	height := len(design.GetShape())
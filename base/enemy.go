@@ -1,6 +1,7 @@
 package base
 
 import (
+	"cmp"
 	"math/rand"
 
 	"github.com/omar0ali/spaceinvaders-game-cli/game/design"
@@ -10,20 +11,37 @@ type Enemy struct {
 	FallingObjectBase
 	Gun
 	design.AlienshipDesign
+	Animator Animator
+}
+
+// Animate plays the animation of the damage state reached, or the death animation.
+func (e *Enemy) Animate(delta float64) {
+	name := e.AnimationFor(e.Health, e.MaxHealth)
+	if e.IsDead() {
+		name = design.AnimationDeath
+	}
+	e.Animator.Animate(&e.Design, name, delta)
+}
+
+// Dying reports whether the death animation is still playing.
+func (e *Enemy) Dying() bool {
+	return e.IsDead() && !e.Animator.Ended(&e.Design)
+}
+
+func (e *Enemy) Sprite() Sprite {
+	return e.Flashed(e.Animator.Sprite(&e.Design))
 }
 
 func Deploy(designs []design.AlienshipDesign, level float64, currentShips ...*Enemy) *Enemy {
 	w, _ := GetSize()
-	const padding = 30
-
-	distance := (w - (padding * 2))
 
 	// choosing the position to place the ship
 	var xPos int
 	const tolerance = 25 // how much space does it need each ship
 
-	for {
-		xPos = rand.Intn(distance) + padding
+	// a narrow playfield may not fit all the ships apart, they overlap after a few tries
+	for range 20 {
+		xPos = SpawnX(w)
 		overlap := false
 
 		for _, ship := range currentShips {
@@ -69,6 +87,9 @@ func Deploy(designs []design.AlienshipDesign, level float64, currentShips ...*En
 			design.GunReloadCooldown-int(level)),
 		AlienshipDesign: design,
 	}
+	enemy.SetDefense(design.Defense)
+	enemy.SetSound(cmp.Or(design.Sound, "alien_fire"))
+	enemy.SetDamageType(design.DamageType)
 
 	return enemy
 }
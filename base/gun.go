@@ -6,6 +6,7 @@ import (
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/omar0ali/spaceinvaders-game-cli/game"
+	"github.com/omar0ali/spaceinvaders-game-cli/game/design"
 )
 
 type Direction = int
@@ -15,38 +16,151 @@ const (
 	Down
 )
 
-type beam struct {
+type Beam struct {
 	position  Point
 	Symbol    rune
 	Direction Direction
+	Power     int
+	Type      design.DamageType
+	Pierce    int    // how many extra targets the beam can go through
+	Homing    bool   // bends towards the closest target (see Steer)
+	Effect    string // particle effect on impact, the default hit when empty
+
+	dx     float64 // horizontal speed (columns per second)
+	fx     float64 // keeps track of the fraction of the horizontal movement
+	struck []any
 }
 
-func (b *beam) GetPosition() *Point {
+type BeamOption func(b *Beam)
+
+func WithSymbol(symbol rune) BeamOption {
+	return func(b *Beam) {
+		b.Symbol = symbol
+	}
+}
+
+func WithPower(power int) BeamOption {
+	return func(b *Beam) {
+		b.Power = power
+	}
+}
+
+func WithPierce(pierce int) BeamOption {
+	return func(b *Beam) {
+		b.Pierce = pierce
+	}
+}
+
+func WithHoming() BeamOption {
+	return func(b *Beam) {
+		b.Homing = true
+	}
+}
+
+func WithDrift(dx float64) BeamOption {
+	return func(b *Beam) {
+		b.dx = dx
+	}
+}
+
+func (b *Beam) GetPosition() *Point {
 	return &b.position
 }
 
+func (b *Beam) Damage() Damage {
+	return Damage{Amount: b.Power, Type: b.Type}
+}
+
+// HasStruck reports whether the beam already hit the target, used by piercing beams
+// to avoid hitting the same target on every frame while passing through it.
+func (b *Beam) HasStruck(target any) bool {
+	for _, t := range b.struck {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
+
 type Gun struct {
-	beams  []*beam
+	beams  []*Beam
 	cap    int
 	loaded int
 	power  int
 	speed  int
+	sound  string
+	effect string
+	damage design.DamageType
+	record GunRecord
 
 	reloading       bool
 	mu              sync.Mutex
 	lastShot        time.Time
 	cooldown        time.Duration
 	reloadCooldown  time.Duration
+	timeScale       float64 // below 1 stretches the cooldown (slow motion), 1 when zero
 	reloadStartTime time.Time
 }
 
+// GunStats is a snapshot of the gun stats, used to revert temporary changes.
+type GunStats struct {
+	Cap, Power, Speed        int
+	Cooldown, ReloadCooldown time.Duration
+}
+
+// GunRecord counts what the gun did during the run, used for the run report.
+type GunRecord struct {
+	Fired  int // beams shot
+	Hits   int // beams that hit at least one target
+	Damage int // damage of all the hits, before defenses
+}
+
+func (g *Gun) GetRecord() GunRecord {
+	return g.record
+}
+
+func (g *Gun) GetStats() GunStats {
+	return GunStats{
+		Cap:            g.cap,
+		Power:          g.power,
+		Speed:          g.speed,
+		Cooldown:       g.cooldown,
+		ReloadCooldown: g.reloadCooldown,
+	}
+}
+
+// Diff returns the change made to the gun since the snapshot was taken.
+func (g *Gun) Diff(snapshot GunStats) GunStats {
+	return GunStats{
+		Cap:            g.cap - snapshot.Cap,
+		Power:          g.power - snapshot.Power,
+		Speed:          g.speed - snapshot.Speed,
+		Cooldown:       g.cooldown - snapshot.Cooldown,
+		ReloadCooldown: g.reloadCooldown - snapshot.ReloadCooldown,
+	}
+}
+
+// Revert takes back a change returned by Diff.
+func (g *Gun) Revert(diff GunStats) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.cap -= diff.Cap
+	g.power -= diff.Power
+	g.speed -= diff.Speed
+	g.cooldown -= diff.Cooldown
+	g.reloadCooldown -= diff.ReloadCooldown
+	g.loaded = min(g.loaded, g.cap)
+}
+
 func NewGun(cap, power, speed int, cooldown, reloadCooldown int) Gun {
 	return Gun{
-		beams:          []*beam{},
+		beams:          []*Beam{},
 		cap:            cap,
 		loaded:         cap,
 		power:          power,
 		speed:          speed,
+		sound:          "player_fire",
+		damage:         design.Kinetic,
 		cooldown:       time.Duration(cooldown) * time.Millisecond,
 		reloadCooldown: time.Duration(reloadCooldown) * time.Millisecond,
 	}
@@ -115,10 +229,31 @@ func (g *Gun) GetLoaded() int {
 	return g.loaded
 }
 
-func (g *Gun) GetBeams() []*beam {
+func (g *Gun) GetBeams() []*Beam {
 	return g.beams
 }
 
+func (g *Gun) SetSound(name string) {
+	g.sound = name
+}
+
+// SetImpactEffect is the particle effect from effects.json where the beams hit.
+func (g *Gun) SetImpactEffect(name string) {
+	g.effect = name
+}
+
+// SetTimeScale slows the firing rate down with the rest of the game, i.e 0.5 fires
+// half as often.
+func (g *Gun) SetTimeScale(scale float64) {
+	g.timeScale = scale
+}
+
+func (g *Gun) SetDamageType(t design.DamageType) {
+	if t != "" {
+		g.damage = t
+	}
+}
+
 func (g *Gun) IsReloading() bool {
 	return g.reloading
 }
@@ -126,7 +261,7 @@ func (g *Gun) IsReloading() bool {
 func (g *Gun) ReloadGun(sounds *game.SoundSystem) {
 	if !g.reloading {
 		g.reloading = true
-		sounds.PlaySound("sfx-tank-reload.mp3", 1)
+		sounds.PlaySound("gun_reload")
 		done := make(chan struct{})
 		go DoOnce(g.reloadCooldown, func() {
 			g.mu.Lock()
@@ -137,20 +272,19 @@ func (g *Gun) ReloadGun(sounds *game.SoundSystem) {
 	}
 }
 
-func (g *Gun) InitBeam(pos Point, dir Direction, sounds *game.SoundSystem) {
+func (g *Gun) InitBeam(pos Point, dir Direction, sounds *game.SoundSystem, opts ...BeamOption) {
+	g.InitBeams([]Point{pos}, dir, sounds, func(i int) []BeamOption {
+		return opts
+	})
+}
+
+// InitBeams shoots a beam from each position as a single shot, (i.e spread shot).
+// Only one round is consumed, opts returns the options of the beam at index i.
+func (g *Gun) InitBeams(positions []Point, dir Direction, sounds *game.SoundSystem, opts func(i int) []BeamOption) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	if g.IsReloading() {
-		return
-	}
-
-	if time.Since(g.lastShot) < g.cooldown {
-		return
-	}
-
-	if g.loaded <= 0 {
-		g.ReloadGun(sounds)
+	if !g.ready(sounds) {
 		return
 	}
 
@@ -159,24 +293,71 @@ func (g *Gun) InitBeam(pos Point, dir Direction, sounds *game.SoundSystem) {
 		symbol = '↓'
 	}
 
-	beam := beam{
-		position: Point{
-			X: pos.X,
-			Y: pos.Y,
-		},
-		Symbol:    symbol,
-		Direction: dir,
+	for i, pos := range positions {
+		beam := &Beam{
+			position: Point{
+				X: pos.X,
+				Y: pos.Y,
+			},
+			Symbol:    symbol,
+			Direction: dir,
+			Power:     g.power,
+			Type:      g.damage,
+			Effect:    g.effect,
+			fx:        float64(pos.X),
+		}
+		for _, o := range opts(i) {
+			o(beam)
+		}
+		g.beams = append(g.beams, beam)
+		g.record.Fired++
+	}
+
+	sounds.PlaySound(g.sound)
+
+	g.lastShot = time.Now()
+	g.loaded -= 1
+}
+
+// Trigger consumes one round without shooting a beam, used by weapons that act
+// on the moment they are fired (i.e bombs). Returns true if the round was fired.
+func (g *Gun) Trigger(sounds *game.SoundSystem) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.ready(sounds) {
+		return false
 	}
 
-	sounds.PlaySound("8-bit-explosion-1.mp3", -1)
-	// sounds.PlaySound("8-bit-laser.mp3", -1)
+	sounds.PlaySound(g.sound)
 
-	g.beams = append(g.beams, &beam)
 	g.lastShot = time.Now()
 	g.loaded -= 1
+	return true
 }
 
-func (g *Gun) RemoveBeam(beam *beam) {
+// ready checks the cooldown and the ammo, will start reloading the gun when empty.
+func (g *Gun) ready(sounds *game.SoundSystem) bool {
+	if g.IsReloading() {
+		return false
+	}
+
+	cooldown := g.cooldown
+	if g.timeScale > 0 {
+		cooldown = time.Duration(float64(cooldown) / g.timeScale)
+	}
+	if time.Since(g.lastShot) < cooldown {
+		return false
+	}
+
+	if g.loaded <= 0 {
+		g.ReloadGun(sounds)
+		return false
+	}
+	return true
+}
+
+func (g *Gun) RemoveBeam(beam *Beam) {
 	for i, b := range g.beams {
 		if beam == b {
 			g.beams = append(g.beams[:i], g.beams[i+1:]...)
@@ -185,10 +366,47 @@ func (g *Gun) RemoveBeam(beam *beam) {
 	}
 }
 
+// Spend is called when a beam hits the target. Piercing beams keep flying until
+// they run out of pierce, otherwise the beam is removed.
+func (g *Gun) Spend(beam *Beam, target any) {
+	if len(beam.struck) == 0 {
+		g.record.Hits++
+	}
+	g.record.Damage += beam.Power
+	beam.struck = append(beam.struck, target)
+	if beam.Pierce > 0 {
+		beam.Pierce--
+		return
+	}
+	g.RemoveBeam(beam)
+}
+
+func (g *Gun) ClearBeams() {
+	g.beams = nil
+}
+
+// Steer bends homing beams towards the x position returned by target.
+func (g *Gun) Steer(target func(from Point) (int, bool)) {
+	for _, beam := range g.beams {
+		if !beam.Homing {
+			continue
+		}
+		x, ok := target(beam.position)
+		switch {
+		case !ok || x == beam.position.X:
+			beam.dx = 0
+		case x < beam.position.X:
+			beam.dx = -float64(g.speed) / 2
+		default:
+			beam.dx = float64(g.speed) / 2
+		}
+	}
+}
+
 func (g *Gun) Update(gc *game.GameContext, delta float64) {
 	// update the coordinates of the beam
-	_, h := GetSize()
-	var activeBeams []*beam
+	w, h := GetSize()
+	var activeBeams []*Beam
 	for _, beam := range g.beams {
 		distance := int(float64(g.speed) * delta)
 		switch beam.Direction {
@@ -197,7 +415,12 @@ func (g *Gun) Update(gc *game.GameContext, delta float64) {
 		case Down:
 			beam.position.Y += distance
 		}
-		if beam.position.Y >= 0 && beam.position.Y <= h {
+		if beam.dx != 0 {
+			beam.fx += beam.dx * delta
+			beam.position.X = int(beam.fx)
+		}
+		if beam.position.Y >= 0 && beam.position.Y <= h &&
+			beam.position.X >= 0 && beam.position.X < w {
 			activeBeams = append(activeBeams, beam)
 		}
 	}
@@ -2,9 +2,11 @@
 package base
 
 import (
+	"math/rand"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/omar0ali/spaceinvaders-game-cli/game/design"
 )
 
 type ObjectEntity struct {
@@ -15,8 +17,12 @@ type ObjectEntity struct {
 
 type ObjectBase struct {
 	ObjectEntity
-	Health    int
-	MaxHealth int
+	Shield
+	Health      int
+	MaxHealth   int
+	Armor       int
+	Resistances map[design.DamageType]float64
+	flashUntil  time.Time
 }
 
 type FallingObjectBase struct {
@@ -39,10 +45,6 @@ func (f *ObjectBase) IsDead() bool {
 	return f.Health <= 0
 }
 
-func (f *ObjectBase) TakeDamage(by int) {
-	f.Health -= by
-}
-
 func (f *ObjectEntity) GetWidth() int {
 	return f.Width
 }
@@ -76,6 +78,7 @@ func DisplayHealthLeft(base *ObjectBase, y int, name string, barSize int, showPe
 		WithStatus(true),
 		WithStyle(style),
 	)
+	base.DisplayShield(2, y+2, barSize)
 }
 
 func DisplayHealthTop(base *ObjectBase, name string, barSize int, showPercentage bool, style tcell.Style, gun *Gun) {
@@ -92,19 +95,19 @@ func DisplayHealthTop(base *ObjectBase, name string, barSize int, showPercentage
 		WithStatus(true),
 		WithStyle(style),
 	)
+	base.DisplayShield((w/2)-(barSize+1)/2, 2, barSize)
 }
 
 func (f *ObjectBase) DisplayHealth(barSize int, style tcell.Style, gun *Gun) {
+	x := int(f.Position.GetX()) + (f.Width / 2) - (barSize / 2) - 1
 	DisplayBar(
 		f,
-		WithPosition(
-			int(f.Position.GetX())+(f.Width/2)-(barSize/2)-1,
-			int(f.Position.GetY()-1),
-		),
+		WithPosition(x, int(f.Position.GetY()-1)),
 		WithBarSize(barSize),
 		WithStyle(style),
 		WithGun(gun),
 	)
+	f.DisplayShield(x, int(f.Position.GetY()-2), barSize)
 }
 
 // Will use this for i.e alien ship shooting every # seconds
@@ -132,3 +135,11 @@ func DoOnce(delay time.Duration, fn func(), done <-chan struct{}) {
 		return
 	}
 }
+
+// SpawnX returns a random x position to deploy an object at, away from the sides of
+// the playfield. The padding shrinks with the width so a narrow playfield still works.
+func SpawnX(w int) int {
+	padding := min(30, w/4)
+	distance := max(w-padding*2, 1)
+	return rand.Intn(distance) + padding
+}
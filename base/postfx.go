@@ -0,0 +1,99 @@
+package base
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/omar0ali/spaceinvaders-game-cli/game"
+)
+
+const (
+	flashDuration = 100 * time.Millisecond
+	vignetteDepth = 2 // cells from the border of the playfield
+)
+
+// postFX is applied by the renderer: the shake offsets the layers under the HUD and the
+// vignette tints the border of the playfield.
+type postFX struct {
+	shakeIntensity float64 // cells
+	shakeStart     time.Time
+	shakeEnd       time.Time
+	shakeX, shakeY int
+	vignette       float64 // 0 to 1, off when 0
+}
+
+// Shake offsets the playfield by up to intensity cells, fading out over the duration.
+// A weaker shake doesn't cut a stronger one short.
+func Shake(intensity float64, duration time.Duration) {
+	fx := &render.fx
+	now := time.Now()
+	if now.Before(fx.shakeEnd) && fx.strength(now) > intensity {
+		return
+	}
+	fx.shakeIntensity, fx.shakeStart, fx.shakeEnd = intensity, now, now.Add(duration)
+}
+
+// SetVignette tints the border of the playfield red, strength from 0 (off) to 1.
+func SetVignette(strength float64) {
+	render.fx.vignette = min(max(strength, 0), 1)
+}
+
+func (fx *postFX) strength(now time.Time) float64 {
+	total := fx.shakeEnd.Sub(fx.shakeStart)
+	if total <= 0 || !now.Before(fx.shakeEnd) {
+		return 0
+	}
+	return fx.shakeIntensity * float64(fx.shakeEnd.Sub(now)) / float64(total)
+}
+
+// begin picks the offset of the frame.
+func (fx *postFX) begin(now time.Time) {
+	fx.shakeX, fx.shakeY = 0, 0
+	if s := fx.strength(now); s > 0 {
+		n := int(s + 0.5)
+		fx.shakeX, fx.shakeY = rand.Intn(2*n+1)-n, rand.Intn(2*n+1)-n
+	}
+}
+
+func (fx *postFX) offset(layer game.Layer) (int, int) {
+	if layer >= game.LayerHUD {
+		return 0, 0
+	}
+	return fx.shakeX, fx.shakeY
+}
+
+// tint is the style of a cell at x, y of the playfield (w by h) with the vignette.
+func (fx *postFX) tint(style tcell.Style, x, y, w, h int) tcell.Style {
+	if fx.vignette <= 0 {
+		return style
+	}
+	d := min(x, y, w-1-x, h-1-y)
+	if d < 0 || d >= vignetteDepth {
+		return style
+	}
+	red := fx.vignette * float64(vignetteDepth-d) / vignetteDepth
+	return style.Background(tcell.NewRGBColor(int32(200*red), 0, 0))
+}
+
+// flash draws the sprite in white while the object was just hit.
+type flash struct {
+	Sprite
+}
+
+func (f flash) StyleAt(row, col int) tcell.Style {
+	return f.Sprite.StyleAt(row, col).Foreground(tcell.ColorWhite).Bold(true)
+}
+
+// Flash makes the sprites of the object flash briefly, i.e when it is hit.
+func (o *ObjectBase) Flash() {
+	o.flashUntil = time.Now().Add(flashDuration)
+}
+
+// Flashed is the sprite drawn flashing while the object was just hit.
+func (o *ObjectBase) Flashed(s Sprite) Sprite {
+	if time.Now().Before(o.flashUntil) {
+		return flash{s}
+	}
+	return s
+}
@@ -0,0 +1,156 @@
+package base
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/omar0ali/spaceinvaders-game-cli/game"
+)
+
+// rough cost of a changed cell on the wire: moving the cursor and changing the colors
+const (
+	cursorBytes = 8
+	styleBytes  = 12
+)
+
+type cell struct {
+	r     rune
+	style tcell.Style
+	set   bool
+}
+
+type RenderStats struct {
+	CellsChanged   int     // cells sent to the terminal in the last frame
+	BytesPerSecond float64 // estimated, averaged over the last second
+	FramesSkipped  int     // frames not sent in low bandwidth mode, in the last second
+	FPS            int     // frames sent in the last second
+}
+
+// renderer draws into layered buffers, then sends only the cells that changed since
+// the last frame that was shown.
+type renderer struct {
+	width, height int // terminal
+	field         struct{ x, y, w, h int }
+	layers        [game.LayerCount][]cell
+	front         []cell // what the terminal shows
+	current       game.Layer
+	fx            postFX
+
+	// low bandwidth mode skips frames to stay under the budget (bytes per second)
+	budget    float64
+	nextFrame time.Time
+
+	stats       RenderStats
+	windowStart time.Time
+	windowBytes int
+	frames      int
+	skipped     int
+}
+
+var render renderer
+
+// LowBandwidth caps the frame rate so the estimated output stays under kbps (KB/s).
+func LowBandwidth(kbps int) OptsFunc {
+	return func(opts *WindowOpts) {
+		opts.Bandwidth = kbps * 1024
+	}
+}
+
+// SetLayer selects the layer that SetContent draws into.
+func SetLayer(layer game.Layer) {
+	render.current = layer
+}
+
+func GetRenderStats() RenderStats {
+	return render.stats
+}
+
+// begin clears the layers, the buffers follow the size of the screen.
+func (r *renderer) begin(now time.Time) {
+	w, h := screen.Size()
+	if w != r.width || h != r.height {
+		r.width, r.height = w, h
+		for i := range r.layers {
+			r.layers[i] = make([]cell, w*h)
+		}
+		r.front = make([]cell, w*h) // zero cells never match, everything is sent again
+		screen.Clear()
+	}
+	r.field.x, r.field.y, r.field.w, r.field.h = playfield()
+	r.fx.begin(now)
+	for _, layer := range r.layers {
+		clear(layer)
+	}
+	r.current = game.LayerActors
+}
+
+// set draws in playfield coordinates, outside of it is clipped (letterbox).
+func (r *renderer) set(x, y int, ch rune, style tcell.Style) {
+	dx, dy := r.fx.offset(r.current)
+	x, y = x+dx, y+dy
+	if x < 0 || y < 0 || x >= r.field.w || y >= r.field.h {
+		return
+	}
+	x, y = x+r.field.x, y+r.field.y
+	if x >= r.width || y >= r.height {
+		return
+	}
+	r.layers[r.current][y*r.width+x] = cell{r: ch, style: style, set: true}
+}
+
+// setTerminal draws in terminal coordinates, over the letterbox and without the shake.
+func (r *renderer) setTerminal(x, y int, ch rune, style tcell.Style) {
+	if x < 0 || y < 0 || x >= r.width || y >= r.height {
+		return
+	}
+	r.layers[r.current][y*r.width+x] = cell{r: ch, style: style, set: true}
+}
+
+// present composes the layers and sends the changed cells to the terminal.
+func (r *renderer) present(now time.Time) {
+	if now.Sub(r.windowStart) >= time.Second {
+		elapsed := now.Sub(r.windowStart).Seconds()
+		r.stats.BytesPerSecond = float64(r.windowBytes) / elapsed
+		r.stats.FPS, r.stats.FramesSkipped = r.frames, r.skipped
+		r.windowStart, r.windowBytes, r.frames, r.skipped = now, 0, 0, 0
+	}
+	if r.budget > 0 && now.Before(r.nextFrame) {
+		r.skipped++
+		return
+	}
+
+	changed, bytes := 0, 0
+	var lastStyle tcell.Style
+	for i := range r.front {
+		c := cell{r: ' ', style: tcell.StyleDefault, set: true}
+		for layer := game.LayerCount - 1; layer >= 0; layer-- {
+			if top := r.layers[layer][i]; top.set {
+				c = top
+				break
+			}
+		}
+		c.style = r.fx.tint(c.style, i%r.width-r.field.x, i/r.width-r.field.y, r.field.w, r.field.h)
+		if c == r.front[i] {
+			continue
+		}
+		r.front[i] = c
+		screen.SetContent(i%r.width, i/r.width, c.r, nil, c.style)
+
+		changed++
+		bytes += cursorBytes + utf8.RuneLen(c.r)
+		if c.style != lastStyle {
+			bytes += styleBytes
+			lastStyle = c.style
+		}
+	}
+	screen.Show()
+
+	r.frames++
+	r.windowBytes += bytes
+	r.stats.CellsChanged = changed
+	if r.budget > 0 {
+		// the next frame waits as long as this one takes to send within the budget
+		r.nextFrame = now.Add(time.Duration(float64(bytes) / r.budget * float64(time.Second)))
+	}
+}
@@ -14,6 +14,10 @@ type OptsFunc func(*WindowOpts)
 type WindowOpts struct {
 	TickerDurationMil time.Duration
 	EnableMouse       bool
+	Bandwidth         int // bytes per second in low bandwidth mode, unlimited when 0
+	AspectRatio       float64
+	MinWidth          int
+	MinHeight         int
 }
 
 var (
@@ -23,6 +27,10 @@ var (
 	ticker      *time.Ticker
 	style       tcell.Style
 	Delta       float64
+
+	// playfield, see Playfield
+	aspectRatio         float64
+	minWidth, minHeight int
 )
 
 func ChangeTickerDuration(duration time.Duration) OptsFunc {
@@ -35,6 +43,16 @@ func EnableMouse(opts *WindowOpts) {
 	opts.EnableMouse = true
 }
 
+// Playfield keeps the game area at the aspect ratio (width/height in cells), it is
+// letterboxed in the terminal. It fills the terminal when the ratio is 0. Terminals
+// smaller than the minimum size are reported by TooSmall.
+func Playfield(aspect float64, minW, minH int) OptsFunc {
+	return func(opts *WindowOpts) {
+		opts.AspectRatio = aspect
+		opts.MinWidth, opts.MinHeight = minW, minH
+	}
+}
+
 func defautlOpts() WindowOpts {
 	return WindowOpts{
 		TickerDurationMil: 33,
@@ -60,6 +78,9 @@ func InitScreen(opts ...OptsFunc) tcell.Screen {
 			screen.EnableMouse()
 		}
 		screen.SetTitle("not set")
+		render.budget = float64(o.Bandwidth)
+		aspectRatio = o.AspectRatio
+		minWidth, minHeight = o.MinWidth, o.MinHeight
 		ticker = time.NewTicker(o.TickerDurationMil * time.Millisecond)
 		style = tcell.StyleDefault.Background(tcell.ColorReset).Foreground(tcell.ColorGreenYellow)
 	})
@@ -84,13 +105,48 @@ func GetTicker() *time.Ticker {
 	return ticker
 }
 
+// GetSize returns the size of the playfield, everything is drawn relative to it.
 func GetSize() (int, int) {
 	if screen == nil {
 		log.Fatal("[SCREEN] Screen must be initialized first. Call InitScreen()")
 	}
+	_, _, w, h := playfield()
+	return w, h
+}
+
+// playfield returns the area of the terminal the game is drawn in.
+func playfield() (x, y, w, h int) {
+	tw, th := screen.Size()
+	if aspectRatio <= 0 {
+		return 0, 0, tw, th
+	}
+	w = min(tw, int(float64(th)*aspectRatio+0.5))
+	h = min(th, int(float64(tw)/aspectRatio+0.5))
+	return (tw - w) / 2, (th - h) / 2, w, h
+}
+
+// GetTerminalSize returns the size of the whole terminal, including the letterbox.
+func GetTerminalSize() (int, int) {
 	return screen.Size()
 }
 
+// TooSmall reports whether the terminal is smaller than the minimum size.
+func TooSmall() bool {
+	w, h := screen.Size()
+	return w < minWidth || h < minHeight
+}
+
+func GetMinSize() (int, int) {
+	return minWidth, minHeight
+}
+
+// MousePosition returns the position of the mouse in the playfield.
+func MousePosition(ev *tcell.EventMouse) (int, int) {
+	x, y := ev.Position()
+	ox, oy, _, _ := playfield()
+	return x - ox, y - oy
+}
+
 func SetTitle(title string) {
 	if screen == nil {
 		log.Fatal("[TITLE] Screen must be initialized first. Call InitScreen()")
@@ -112,10 +168,8 @@ func InputEvent(exitCha chan struct{}, keys func(tcell.Event)) {
 	go func() {
 		for {
 			event := screen.PollEvent()
-			switch ev := event.(type) {
-			case *tcell.EventResize:
-				screen.Clear()
-			case *tcell.EventKey:
+			// resizing is handled by the renderer on the next frame
+			if ev, ok := event.(*tcell.EventKey); ok {
 				if ev.Key() == tcell.KeyCtrlQ {
 					ExitGame(exitCha)
 					return
@@ -139,11 +193,11 @@ func Update(exitCha chan struct{}, updates func(delta float64)) {
 				Delta = now.Sub(last).Seconds()
 				last = now
 
-				screen.Clear()
+				render.begin(now)
 
 				updates(Delta)
 
-				screen.Show()
+				render.present(now)
 			case <-exitCha:
 				cleanupOnce.Do(func() {
 					screen.Fini()
@@ -158,14 +212,23 @@ func SetContent(x, y int, r rune) {
 	if screen == nil {
 		log.Fatal("[SetContent] Screen must be initialized first. Call InitScreen()")
 	}
-	screen.SetContent(x, y, r, nil, style)
+	render.set(x, y, r, style)
 }
 
 func SetContentWithStyle(x, y int, r rune, style tcell.Style) {
 	if screen == nil {
 		log.Fatal("[SetContentWithStyle] Screen must be initialized first. Call InitScreen()")
 	}
-	screen.SetContent(x, y, r, nil, style)
+	render.set(x, y, r, style)
+}
+
+// SetTerminalContent draws in terminal coordinates instead of the playfield, i.e the
+// warning shown when the terminal is too small for the letterboxed playfield.
+func SetTerminalContent(x, y int, r rune, style tcell.Style) {
+	if screen == nil {
+		log.Fatal("[SetTerminalContent] Screen must be initialized first. Call InitScreen()")
+	}
+	render.setTerminal(x, y, r, style)
 }
 
 func StyleIt(forground tcell.Color) tcell.Style {
@@ -0,0 +1,20 @@
+package base
+
+import "github.com/gdamore/tcell/v2"
+
+// Sprite is a shape drawn with the styles of its color map, i.e any design.
+type Sprite interface {
+	GetShape() []string
+	StyleAt(row, col int) tcell.Style
+}
+
+// DrawSprite draws the sprite with its top left corner at x, y. Spaces are transparent.
+func DrawSprite(x, y int, s Sprite) {
+	for row, line := range s.GetShape() {
+		for col, char := range []rune(line) {
+			if char != ' ' {
+				SetContentWithStyle(x+col, y+row, char, s.StyleAt(row, col))
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package base
+
+import (
+	"time"
+
+	"github.com/omar0ali/spaceinvaders-game-cli/game"
+	"github.com/omar0ali/spaceinvaders-game-cli/game/design"
+)
+
+type Weapon struct {
+	Gun
+	design.WeaponDesign
+	charge float64 // seconds the trigger has been held (charge shot)
+}
+
+func NewWeapon(d design.WeaponDesign) *Weapon {
+	w := &Weapon{
+		Gun: NewGun(
+			d.GunCap,
+			d.GunPower,
+			d.GunSpeed,
+			d.GunCooldown,
+			d.GunReloadCooldown,
+		),
+		WeaponDesign: d,
+	}
+	if d.Sound != "" {
+		w.SetSound(d.Sound)
+	}
+	w.SetImpactEffect(d.ImpactEffect)
+	w.SetDamageType(d.DamageType)
+	return w
+}
+
+// Fire is called on every update, held is true while the trigger is pressed.
+func (w *Weapon) Fire(pos Point, held bool, delta float64, sounds *game.SoundSystem) {
+	switch w.Kind {
+	case design.Charge:
+		w.fireCharge(pos, held, delta, sounds)
+	case design.Spread:
+		if held {
+			w.fireSpread(pos, sounds)
+		}
+	case design.Piercing:
+		if held {
+			w.InitBeam(pos, Up, sounds, WithSymbol(w.GetSymbol()), WithPierce(w.Pierce))
+		}
+	case design.Homing:
+		if held {
+			w.InitBeam(pos, Up, sounds, WithSymbol(w.GetSymbol()), WithHoming())
+		}
+	default:
+		if held {
+			w.InitBeam(pos, Up, sounds, WithSymbol(w.GetSymbol()))
+		}
+	}
+}
+
+func (w *Weapon) fireSpread(pos Point, sounds *game.SoundSystem) {
+	n := max(w.Spread, 1)
+	positions := make([]Point, n)
+	for i := range positions {
+		positions[i] = pos
+	}
+	w.InitBeams(positions, Up, sounds, func(i int) []BeamOption {
+		// spread beams evenly to the left and right of the center
+		offset := float64(i) - float64(n-1)/2
+		return []BeamOption{
+			WithSymbol(w.GetSymbol()),
+			WithDrift(offset * float64(w.GetSpeed()) / 4),
+		}
+	})
+}
+
+// fireCharge builds up the charge while held, the beam is released when the trigger
+// is let go or when fully charged.
+func (w *Weapon) fireCharge(pos Point, held bool, delta float64, sounds *game.SoundSystem) {
+	if held && !w.IsReloading() {
+		w.charge += delta
+		if w.ChargeRatio() < 1 {
+			return
+		}
+	}
+	if w.charge == 0 {
+		return
+	}
+	multiplier := 1 + w.ChargeRatio()*float64(max(w.ChargeMultiplier-1, 0))
+	w.InitBeam(pos, Up, sounds,
+		WithSymbol(w.GetSymbol()),
+		WithPower(int(float64(w.GetPower())*multiplier)),
+	)
+	w.charge = 0
+}
+
+// ChargeRatio returns the progress of the charge shot between 0 and 1.
+func (w *Weapon) ChargeRatio() float64 {
+	chargeTime := time.Duration(w.ChargeTime) * time.Millisecond
+	if chargeTime <= 0 {
+		return 0
+	}
+	return min(w.charge/chargeTime.Seconds(), 1)
+}
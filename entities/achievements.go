@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"fmt"
+
+	"github.com/omar0ali/spaceinvaders-game-cli/entities/ui"
+	"github.com/omar0ali/spaceinvaders-game-cli/game"
+	"github.com/omar0ali/spaceinvaders-game-cli/game/design"
+)
+
+// Achieve feeds a gameplay event to the achievements. The value is added to the counter,
+// except for level_reached where the counter keeps the highest level.
+func (s *SpaceShip) Achieve(gc *game.GameContext, event design.AchievementEvent, target string, value int) {
+	profile := gc.Profile
+	if profile == nil {
+		return
+	}
+	for _, a := range s.LoadedDesigns.ListOfAchievements {
+		if a.Event != event || profile.Achievements[a.ID] {
+			continue
+		}
+		if a.Target != "" && a.Target != target {
+			continue
+		}
+
+		if event == design.EventLevelReached {
+			profile.Counters[a.ID] = max(profile.Counters[a.ID], value)
+		} else {
+			profile.Counters[a.ID] += value
+		}
+
+		if profile.Counters[a.ID] >= max(a.Count, 1) && profile.UnlockAchievement(a.ID) {
+			gc.Sounds.PlaySound("achievement")
+			SetStatus(fmt.Sprintf("Achievement Unlocked: %s", a.Name), gc)
+		}
+	}
+}
+
+// achievementsItems lists the achievements with their progress for the compendium.
+func achievementsItems(designs *design.LoadedDesigns, profile *game.Profile) []*ui.Box {
+	items := make([]*ui.Box, 0)
+	for _, a := range designs.ListOfAchievements {
+		count := max(a.Count, 1)
+		progress := min(profile.Counters[a.ID], count)
+		status := "Locked"
+		if profile.Achievements[a.ID] {
+			status = "Unlocked"
+		}
+		shape := []string{a.Name, fmt.Sprintf("%d/%d", progress, count)}
+
+		descriptions := []string{
+			fmt.Sprintf("- [%s]", a.Name),
+			fmt.Sprintf("* Description: %s", a.Description),
+			fmt.Sprintf("* Progress:    %d/%d", progress, count),
+			fmt.Sprintf("* Status:      %s", status),
+		}
+		items = append(items, ui.NewUIBox(shape, descriptions, nil))
+	}
+	return items
+}
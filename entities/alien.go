@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"cmp"
 	"fmt"
 	"math"
 
@@ -17,6 +18,7 @@ type AlienProducer struct {
 	Level         float64
 	SelectedAlien *base.Enemy // used to display healthbar for selected ones
 	LoadedDesigns *design.LoadedDesigns
+	dying         []*base.Enemy // playing their death animation before exploding
 }
 
 func NewAlienProducer(gc *game.GameContext, designs *design.LoadedDesigns) *AlienProducer {
@@ -25,27 +27,35 @@ func NewAlienProducer(gc *game.GameContext, designs *design.LoadedDesigns) *Alie
 		LoadedDesigns: designs,
 	}
 
-	if s, ok := gc.FindEntity("spaceship").(*SpaceShip); ok {
-		s.AddOnLevelUp(func(newLevel int) {
-			a.Level += 0.1
-			_, f := math.Modf(a.Level)
-			if f == 0 {
-				SetStatus(fmt.Sprintf("Wave %f", a.Level), gc)
-			}
-		})
-	}
+	game.Subscribe(gc, func(e game.LevelUp) {
+		a.Level += 0.1
+		_, f := math.Modf(a.Level)
+		if f == 0 {
+			SetStatus(fmt.Sprintf("Wave %f", a.Level), gc)
+		}
+	})
+	game.Subscribe(gc, func(e game.Resized) {
+		for _, alien := range append(a.Aliens, a.dying...) {
+			rescale(&alien.Position, e)
+		}
+	})
 	return a
 }
 
 func (a *AlienProducer) Update(gc *game.GameContext, delta float64) {
-	// temporary fix, stop deploying ships until game starts
-	if ui, ok := gc.FindEntity("ui").(*UI); ok {
-		if ui.MenuScreen {
-			return
+	delta = EnemyDelta(gc, delta) // slowed down by the slow motion effect
+
+	dying := a.dying[:0]
+	for _, alien := range a.dying {
+		alien.Animate(delta)
+		if alien.Dying() {
+			dying = append(dying, alien)
+		} else {
+			a.explode(alien, gc)
 		}
-	} else {
-		return
 	}
+	a.dying = dying
+
 	// start deploying
 	if boss, ok := gc.FindEntity("boss").(*BossProducer); ok {
 		// saying if there is a boss alien ship deployed. It should stop alien ships.
@@ -59,12 +69,17 @@ func (a *AlienProducer) Update(gc *game.GameContext, delta float64) {
 	}
 
 	if len(a.Aliens) < int(a.Level) {
-		a.Aliens = append(a.Aliens, base.Deploy(a.LoadedDesigns.ListOfAlienships, a.Level, a.Aliens...))
+		alien := base.Deploy(a.LoadedDesigns.ListOfAlienships, a.Level, a.Aliens...)
+		a.Aliens = append(a.Aliens, alien)
+		game.Logger(game.SubsystemSpawn).Debug("alien deployed", "name", alien.Name, "level", a.Level)
 	}
 
 	// go through each alien's gun and shoot
 	for _, alien := range a.Aliens {
 		alien.Update(gc, delta)
+		alien.Animate(delta)
+		alien.RegenerateShield(delta)
+		alien.SetTimeScale(EnemyTimeScale(gc))
 		alien.InitBeam(base.Point{
 			X: int(alien.Position.X) + (alien.Width / 2),
 			Y: int(alien.Position.Y) + (alien.Height) + 1,
@@ -96,30 +111,14 @@ func (a *AlienProducer) Draw(gc *game.GameContext) {
 
 		alien.DisplayHealth(11, color, &alien.Gun)
 
-		// draw shape
-		for rowIndex, line := range alien.Shape {
-			for colIndex, char := range line {
-				if char != ' ' {
-					x := int(alien.Position.GetX()) + colIndex
-					y := int(alien.Position.GetY()) + rowIndex
-					base.SetContentWithStyle(x, y, char, color)
-				}
-			}
-		}
-
+		base.DrawSprite(int(alien.Position.GetX()), int(alien.Position.GetY()), alien.Sprite())
+	}
+	for _, alien := range a.dying {
+		base.DrawSprite(int(alien.Position.GetX()), int(alien.Position.GetY()), alien.Sprite())
 	}
 }
 
-func (a *AlienProducer) InputEvents(event tcell.Event, gc *game.GameContext) {
-	// testing code
-
-	// switch ev := event.(type) {
-	// case *tcell.EventKey:
-	// 	if ev.Rune() == 'z' { // dev mode
-	// 		base.Deploy("alienships.json", int(a.Level))
-	// 	}
-	// }
-}
+func (a *AlienProducer) InputEvents(event tcell.Event, gc *game.GameContext) {}
 
 func (a *AlienProducer) UIAlienShipData(gc *game.GameContext) {
 	whiteColor := base.StyleIt(tcell.ColorWhite)
@@ -150,11 +149,11 @@ func (a *AlienProducer) MovementAndCollision(delta float64, gc *game.GameContext
 		// Update the coordinates of the aliens.
 		Move(&alien.ObjectBase, delta)
 		for _, beam := range spaceship.GetBeams() {
-			if GettingHit(&alien.ObjectBase, beam, gc) {
+			if !beam.HasStruck(alien) && GettingHit(&alien.ObjectBase, beam, gc) {
 				a.SelectedAlien = alien
-				alien.TakeDamage(spaceship.GetPower())
+				alien.TakeDamage(beam.Damage())
 				spaceship.ScoreHit()
-				spaceship.RemoveBeam(beam) // removing a beam when hitting the ship
+				spaceship.Spend(beam, alien) // removing a beam when hitting the ship
 			}
 		}
 
@@ -162,22 +161,19 @@ func (a *AlienProducer) MovementAndCollision(delta float64, gc *game.GameContext
 		if a, ok := gc.FindEntity("asteroid").(*AsteroidProducer); ok {
 			for _, asteroid := range a.Asteroids {
 				if Crash(&alien.ObjectBase, &asteroid.ObjectBase, gc) {
-					alien.TakeDamage(1)
-					asteroid.TakeDamage(3)
+					alien.TakeDamage(base.CollisionDamage(1))
+					asteroid.TakeDamage(base.CollisionDamage(3))
 				}
 			}
 		}
 
-		// can collid with a meteroid
+		// can collid with debris
 		if ps, ok := gc.FindEntity("particles").(*particles.ParticleSystem); ok {
-			for _, p := range ps.ParticleProducable {
-				switch p.(type) {
-				case *particles.MeteroidProducer:
-					for _, m := range p.GetParticles() {
-						if Crash(&alien.ObjectBase, &m.ObjectEntity, gc) {
-							alien.TakeDamage(1)
-							p.RemoveParticle(m)
-						}
+			for _, p := range ps.Colliding() {
+				for _, m := range p.GetParticles() {
+					if Crash(&alien.ObjectBase, &m.ObjectEntity, gc) {
+						alien.TakeDamage(base.CollisionDamage(1))
+						p.RemoveParticle(m)
 					}
 				}
 			}
@@ -185,22 +181,15 @@ func (a *AlienProducer) MovementAndCollision(delta float64, gc *game.GameContext
 
 		// only if destroyed by the spaceship (player) not an asteroid.
 		if alien.IsDead() {
-			if ps, ok := gc.FindEntity("particles").(*particles.ParticleSystem); ok {
-				ps.AddParticles(
-					particles.InitExplosion(8,
-						particles.WithDimensions(
-							alien.Position.X,
-							alien.Position.Y,
-							alien.Width,
-							alien.Height,
-						),
-					),
-				)
-			}
-			gc.Sounds.PlaySound("8-bit-explosion-2.mp3", -1)
-
 			a.SelectedAlien = nil
-			spaceship.ScoreKill(alien.EntityHealth)
+			game.Publish(gc, game.EnemyKilled{Name: alien.Name, Health: alien.EntityHealth})
+
+			alien.Animate(0)
+			if alien.Dying() {
+				a.dying = append(a.dying, alien)
+			} else {
+				a.explode(alien, gc)
+			}
 		}
 
 		// check the alien ship height position
@@ -209,7 +198,7 @@ func (a *AlienProducer) MovementAndCollision(delta float64, gc *game.GameContext
 		_, h := base.GetSize()
 		if alien.IsOffScreen(h) {
 			a.SelectedAlien = nil
-			spaceship.TakeDamage(1)
+			spaceship.TakeDamage(base.CollisionDamage(1))
 		}
 		if !alien.IsDead() && !alien.IsOffScreen(h) { // still flying
 			activeAliens = append(activeAliens, alien)
@@ -218,6 +207,21 @@ func (a *AlienProducer) MovementAndCollision(delta float64, gc *game.GameContext
 	a.Aliens = activeAliens
 }
 
+func (a *AlienProducer) explode(alien *base.Enemy, gc *game.GameContext) {
+	if ps, ok := gc.FindEntity("particles").(*particles.ParticleSystem); ok {
+		ps.Emit(cmp.Or(alien.DeathEffect, "explosion"), alien.Position.X, alien.Position.Y, alien.Width, alien.Height)
+	}
+	gc.Sounds.PlaySound("alien_explosion", emitterOf(alien))
+}
+
 func (a *AlienProducer) GetType() string {
 	return "alien"
 }
+
+func (a *AlienProducer) GetLayer() game.Layer {
+	return game.LayerActors
+}
+
+func (a *AlienProducer) GetPhase() game.Phase {
+	return game.PhaseCollide
+}
@@ -29,12 +29,15 @@ func NewAsteroidProducer(gc *game.GameContext, designs *design.LoadedDesigns) *A
 		LoadedDesigns: designs,
 	}
 
-	if s, ok := gc.FindEntity("spaceship").(*SpaceShip); ok {
-		s.AddOnLevelUp(func(newLevel int) {
-			a.Level += 0.1
-			game.Log(game.Warn, "Asteroid Level UP: %1.f", a.Level)
-		})
-	}
+	game.Subscribe(gc, func(e game.LevelUp) {
+		a.Level += 0.1
+		game.Logger(game.SubsystemSpawn).Info("asteroid level up", "level", a.Level)
+	})
+	game.Subscribe(gc, func(e game.Resized) {
+		for _, asteroid := range a.Asteroids {
+			rescale(&asteroid.Position, e)
+		}
+	})
 
 	return a
 }
@@ -49,9 +52,7 @@ func (a *AsteroidProducer) Deploy() {
 
 	speed := rand.Float64()*float64(min(a.LoadedDesigns.ListOfAsteroids.MaxSpeed, int(a.Level)+1)) + 2
 
-	const padding = 30
-	distance := (w - (padding * 2))
-	xPos := rand.Intn(distance) + padding
+	xPos := base.SpawnX(w)
 
 	a.Asteroids = append(a.Asteroids, &Asteroid{
 		FallingObjectBase: base.FallingObjectBase{
@@ -71,17 +72,10 @@ func (a *AsteroidProducer) Deploy() {
 }
 
 func (a *AsteroidProducer) Update(gc *game.GameContext, delta float64) {
-	// temporary fix, stop deploying Asteroids when game starts
-	if ui, ok := gc.FindEntity("ui").(*UI); ok {
-		if ui.MenuScreen {
-			return
-		}
-	} else {
-		return
-	}
+	delta = EnemyDelta(gc, delta) // slowed down by the slow motion effect
 
 	if len(a.Asteroids) < min(int(a.Level), a.LoadedDesigns.ListOfAsteroids.MaxLimit) {
-		game.Log(game.Info, "Asteroids Deployed %d Level %.1f", len(a.Asteroids), a.Level)
+		game.Logger(game.SubsystemSpawn).Debug("asteroids deployed", "count", len(a.Asteroids), "level", a.Level)
 		a.Deploy()
 	}
 
@@ -105,23 +99,20 @@ func (a *AsteroidProducer) Update(gc *game.GameContext, delta float64) {
 			}
 			// asteroids can crash at each other and destroy
 			if Crash(&asteroid.ObjectBase, &asteroid2.ObjectEntity, gc) {
-				asteroid.TakeDamage(40)
-				asteroid2.TakeDamage(40)
+				asteroid.TakeDamage(base.CollisionDamage(40))
+				asteroid2.TakeDamage(base.CollisionDamage(40))
 			}
 		}
 
 		Move(&asteroid.ObjectBase, delta)
 
-		// can collid with a meteroid
+		// can collid with debris
 		if ps, ok := gc.FindEntity("particles").(*particles.ParticleSystem); ok {
-			for _, p := range ps.ParticleProducable {
-				switch p.(type) {
-				case *particles.MeteroidProducer:
-					for _, m := range p.GetParticles() {
-						if Crash(&asteroid.ObjectBase, &m.ObjectEntity, gc) {
-							asteroid.TakeDamage(1)
-							p.RemoveParticle(m)
-						}
+			for _, p := range ps.Colliding() {
+				for _, m := range p.GetParticles() {
+					if Crash(&asteroid.ObjectBase, &m.ObjectEntity, gc) {
+						asteroid.TakeDamage(base.CollisionDamage(1))
+						p.RemoveParticle(m)
 					}
 				}
 			}
@@ -129,10 +120,10 @@ func (a *AsteroidProducer) Update(gc *game.GameContext, delta float64) {
 
 		// get get hit from the spaceship
 		for _, beam := range spaceship.GetBeams() {
-			if GettingHit(asteroid, beam, gc) {
+			if !beam.HasStruck(asteroid) && GettingHit(asteroid, beam, gc) {
 				a.SelectedAsteroid = asteroid
-				asteroid.TakeDamage(spaceship.GetPower())
-				spaceship.RemoveBeam(beam)
+				asteroid.TakeDamage(beam.Damage())
+				spaceship.Spend(beam, asteroid)
 			}
 		}
 
@@ -140,7 +131,7 @@ func (a *AsteroidProducer) Update(gc *game.GameContext, delta float64) {
 		for _, alien := range alienProducer.Aliens {
 			for _, beam := range alien.GetBeams() {
 				if GettingHit(asteroid, beam, gc) {
-					asteroid.TakeDamage(alien.GetPower())
+					asteroid.TakeDamage(beam.Damage())
 					alien.RemoveBeam(beam)
 				}
 			}
@@ -150,25 +141,9 @@ func (a *AsteroidProducer) Update(gc *game.GameContext, delta float64) {
 
 		if asteroid.IsDead() {
 			if ps, ok := gc.FindEntity("particles").(*particles.ParticleSystem); ok {
-				ps.AddParticles(
-					particles.InitExplosion(15,
-						particles.WithDimensions(
-							asteroid.Position.X,
-							asteroid.Position.Y,
-							asteroid.Width,
-							asteroid.Height,
-						), particles.WithStyle(base.StyleIt(tcell.ColorWhite)),
-					),
-				)
-
-				ps.AddParticles(particles.InitMeteroids(1,
-					particles.WithDimensions(
-						asteroid.Position.X,
-						asteroid.Position.Y,
-						asteroid.Width,
-						asteroid.Height,
-					)))
-				gc.Sounds.PlaySound("8-bit-asteroid-explosion.mp3", 0)
+				ps.Emit("asteroid_explosion", asteroid.Position.X, asteroid.Position.Y, asteroid.Width, asteroid.Height)
+				ps.Emit("debris", asteroid.Position.X, asteroid.Position.Y, asteroid.Width, asteroid.Height)
+				gc.Sounds.PlaySound("asteroid_explosion", emitterOf(asteroid))
 			}
 
 			a.SelectedAsteroid = nil
@@ -203,18 +178,7 @@ func (a *AsteroidProducer) Draw(gc *game.GameContext) {
 	}
 
 	for _, asteroid := range a.Asteroids {
-		color := base.StyleIt(asteroid.GetColor())
-		// asteroid.DisplayHealth(5, true, color, nil)
-		for rowIndex, line := range asteroid.Shape {
-			for colIndex, char := range line {
-				if char != ' ' {
-					x := int(asteroid.Position.GetX()) + colIndex
-					y := int(asteroid.Position.GetY()) + rowIndex
-					base.SetContentWithStyle(x, y, char, color)
-				}
-			}
-		}
-
+		base.DrawSprite(int(asteroid.Position.GetX()), int(asteroid.Position.GetY()), asteroid.Flashed(asteroid))
 	}
 }
 
@@ -232,3 +196,11 @@ func (a *AsteroidProducer) InputEvents(event tcell.Event, gc *game.GameContext)
 func (a *AsteroidProducer) GetType() string {
 	return "asteroid"
 }
+
+func (a *AsteroidProducer) GetLayer() game.Layer {
+	return game.LayerActors
+}
+
+func (a *AsteroidProducer) GetPhase() game.Phase {
+	return game.PhaseCollide
+}
@@ -1,6 +1,9 @@
 package entities
 
 import (
+	"cmp"
+	"time"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/omar0ali/spaceinvaders-game-cli/base"
 	"github.com/omar0ali/spaceinvaders-game-cli/entities/particles"
@@ -13,12 +16,21 @@ type BossProducer struct {
 	Level           float64
 	LoadedDesigns   *design.LoadedDesigns
 	deploymentTimer int
+	dying           *base.Enemy // playing its death animation before exploding
 }
 
 func (b *BossProducer) GetType() string {
 	return "boss"
 }
 
+func (b *BossProducer) GetLayer() game.Layer {
+	return game.LayerActors
+}
+
+func (b *BossProducer) GetPhase() game.Phase {
+	return game.PhaseCollide
+}
+
 func NewBossAlienProducer(gc *game.GameContext, designs *design.LoadedDesigns) *BossProducer {
 	b := &BossProducer{
 		Level:           1.0,
@@ -26,25 +38,50 @@ func NewBossAlienProducer(gc *game.GameContext, designs *design.LoadedDesigns) *
 		LoadedDesigns:   designs,
 	}
 
-	if s, ok := gc.FindEntity("spaceship").(*SpaceShip); ok {
-		s.AddOnLevelUp(func(newLevel int) {
-			b.Level += 0.1
-		})
-	}
+	game.Subscribe(gc, func(e game.LevelUp) {
+		b.Level += 0.1
+	})
+	game.Subscribe(gc, func(e game.Resized) {
+		for _, boss := range []*base.Enemy{b.BossAlien, b.dying} {
+			if boss != nil {
+				rescale(&boss.Position, e)
+			}
+		}
+	})
+	game.Subscribe(gc, func(e game.BossSpawned) {
+		SetStatus("Warning: Massive energy spike detected.", gc)
+		if b.BossAlien != nil {
+			gc.Sounds.PlaySound("boss_alarm", emitterOf(b.BossAlien))
+		} else {
+			gc.Sounds.PlaySound("boss_alarm")
+		}
+	})
 
 	return b
 }
 
 func (b *BossProducer) Update(gc *game.GameContext, delta float64) {
+	delta = EnemyDelta(gc, delta) // slowed down by the slow motion effect
+	if b.dying != nil {
+		b.dying.Animate(delta)
+		if !b.dying.Dying() {
+			b.explode(b.dying, gc)
+			b.dying = nil
+		}
+	}
+
 	if b.BossAlien == nil && b.deploymentTimer == minutes {
-		SetStatus("Warning: Massive energy spike detected.", gc)
-		gc.Sounds.PlaySound("sfx-alarm.mp3", -1)
 		b.BossAlien = base.Deploy(b.LoadedDesigns.ListOfBossShips, b.Level)
+		game.Publish(gc, game.BossSpawned{Name: b.BossAlien.Name})
+		game.Logger(game.SubsystemSpawn).Info("boss deployed", "name", b.BossAlien.Name, "level", b.Level)
 		b.deploymentTimer += 3
 	}
 
 	if b.BossAlien != nil {
 		b.BossAlien.Update(gc, delta)
+		b.BossAlien.Animate(delta)
+		b.BossAlien.RegenerateShield(delta)
+		b.BossAlien.SetTimeScale(EnemyTimeScale(gc))
 		b.BossAlien.InitBeam(base.Point{
 			X: int(b.BossAlien.Position.X) + (b.BossAlien.Width / 2),
 			Y: int(b.BossAlien.Position.Y) + (b.BossAlien.Height) + 1,
@@ -55,6 +92,9 @@ func (b *BossProducer) Update(gc *game.GameContext, delta float64) {
 }
 
 func (b *BossProducer) Draw(gc *game.GameContext) {
+	if b.dying != nil {
+		base.DrawSprite(int(b.dying.Position.GetX()), int(b.dying.Position.GetY()), b.dying.Sprite())
+	}
 	if b.BossAlien == nil {
 		return
 	}
@@ -65,28 +105,10 @@ func (b *BossProducer) Draw(gc *game.GameContext) {
 
 	b.BossAlien.Draw(gc, b.BossAlien.GetColor())
 
-	// draw shape
-	for rowIndex, line := range b.BossAlien.Shape {
-		for colIndex, char := range line {
-			if char != ' ' {
-				x := int(b.BossAlien.Position.GetX()) + colIndex
-				y := int(b.BossAlien.Position.GetY()) + rowIndex
-				base.SetContentWithStyle(x, y, char, color)
-			}
-		}
-	}
+	base.DrawSprite(int(b.BossAlien.Position.GetX()), int(b.BossAlien.Position.GetY()), b.BossAlien.Sprite())
 }
 
-func (b *BossProducer) InputEvents(event tcell.Event, gc *game.GameContext) {
-	// testing code
-
-	// switch ev := event.(type) {
-	// case *tcell.EventKey:
-	// 	if ev.Rune() == 'm' { // dev mode
-	// 		b.BossAlien = base.Deploy(b.BossShipDesigns, b.Level)
-	// 	}
-	// }
-}
+func (b *BossProducer) InputEvents(event tcell.Event, gc *game.GameContext) {}
 
 func (b *BossProducer) MovementAndCollision(delta float64, gc *game.GameContext) {
 	if spaceship, ok := gc.FindEntity("spaceship").(*SpaceShip); ok {
@@ -94,10 +116,13 @@ func (b *BossProducer) MovementAndCollision(delta float64, gc *game.GameContext)
 		MoveTo(&b.BossAlien.ObjectBase, &spaceship.ObjectBase, delta, gc)
 
 		for _, beam := range spaceship.GetBeams() {
-			if GettingHit(&b.BossAlien.ObjectBase, beam, gc) {
-				b.BossAlien.TakeDamage(spaceship.GetPower())
+			if !beam.HasStruck(b.BossAlien) && GettingHit(&b.BossAlien.ObjectBase, beam, gc) {
+				b.BossAlien.TakeDamage(beam.Damage())
+				if fx, ok := gc.FindEntity("postfx").(*PostFX); ok {
+					fx.Shake(shakeOnBoss, 150*time.Millisecond)
+				}
 				spaceship.ScoreHit()
-				spaceship.RemoveBeam(beam)
+				spaceship.Spend(beam, b.BossAlien)
 			}
 		}
 
@@ -105,45 +130,42 @@ func (b *BossProducer) MovementAndCollision(delta float64, gc *game.GameContext)
 		if a, ok := gc.FindEntity("asteroid").(*AsteroidProducer); ok {
 			for _, asteroid := range a.Asteroids {
 				if Crash(&b.BossAlien.ObjectBase, &asteroid.ObjectBase, gc) {
-					b.BossAlien.TakeDamage(1)
-					asteroid.TakeDamage(100)
+					b.BossAlien.TakeDamage(base.CollisionDamage(1))
+					asteroid.TakeDamage(base.CollisionDamage(100))
 				}
 			}
 		}
 
-		// can collid with a meteroid
+		// can collid with debris
 		if ps, ok := gc.FindEntity("particles").(*particles.ParticleSystem); ok {
-			for _, p := range ps.ParticleProducable {
-				switch p.(type) {
-				case *particles.MeteroidProducer:
-					for _, m := range p.GetParticles() {
-						if Crash(&b.BossAlien.ObjectBase, &m.ObjectEntity, gc) {
-							b.BossAlien.TakeDamage(1)
-							p.RemoveParticle(m)
-						}
+			for _, p := range ps.Colliding() {
+				for _, m := range p.GetParticles() {
+					if Crash(&b.BossAlien.ObjectBase, &m.ObjectEntity, gc) {
+						b.BossAlien.TakeDamage(base.CollisionDamage(1))
+						p.RemoveParticle(m)
 					}
 				}
 			}
 		}
 
 		if b.BossAlien.IsDead() {
-			if ps, ok := gc.FindEntity("particles").(*particles.ParticleSystem); ok {
-				ps.AddParticles(
-					particles.InitExplosion(15,
-						particles.WithDimensions(
-							b.BossAlien.Position.X,
-							b.BossAlien.Position.Y,
-							b.BossAlien.Width,
-							b.BossAlien.Height,
-						),
-					),
-				)
-			}
-			gc.Sounds.PlaySound("8-bit-explosion-low-resonant.mp3", -1)
-
-			spaceship.ScoreKill(b.BossAlien.Health)
+			game.Publish(gc, game.EnemyKilled{Name: b.BossAlien.Name, Health: b.BossAlien.EntityHealth, Boss: true})
 			SetStatus("Threat neutralized. Returning to standby.", gc)
+
+			b.BossAlien.Animate(0)
+			if b.BossAlien.Dying() {
+				b.dying = b.BossAlien
+			} else {
+				b.explode(b.BossAlien, gc)
+			}
 			b.BossAlien = nil
 		}
 	}
 }
+
+func (b *BossProducer) explode(boss *base.Enemy, gc *game.GameContext) {
+	if ps, ok := gc.FindEntity("particles").(*particles.ParticleSystem); ok {
+		ps.Emit(cmp.Or(boss.DeathEffect, "boss_explosion"), boss.Position.X, boss.Position.Y, boss.Width, boss.Height)
+	}
+	gc.Sounds.PlaySound("boss_explosion", emitterOf(boss))
+}
@@ -0,0 +1,485 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/omar0ali/spaceinvaders-game-cli/base"
+	"github.com/omar0ali/spaceinvaders-game-cli/entities/ui"
+	"github.com/omar0ali/spaceinvaders-game-cli/game"
+	"github.com/omar0ali/spaceinvaders-game-cli/game/design"
+)
+
+const consoleLines = 10 // output lines kept on the screen
+
+type command struct {
+	usage    string
+	run      func(c *Console, gc *game.GameContext, args []string) string
+	complete func(c *Console, args []string) []string // candidates of the argument being typed
+}
+
+// Console is a developer overlay toggled with the backtick key (dev.console in the config).
+type Console struct {
+	Open          bool
+	input         []rune
+	output        []string
+	history       []string
+	historyIndex  int
+	commands      map[string]command
+	LoadedDesigns *design.LoadedDesigns
+	scene         game.Scene // pushed while open
+}
+
+func NewConsole(designs *design.LoadedDesigns) *Console {
+	c := &Console{LoadedDesigns: designs}
+	c.commands = map[string]command{
+		"help": {
+			usage: "help",
+			run: func(c *Console, gc *game.GameContext, args []string) string {
+				var usages []string
+				for _, name := range c.commandNames() {
+					usages = append(usages, c.commands[name].usage)
+				}
+				return strings.Join(usages, " | ")
+			},
+		},
+		"spawn": {
+			usage: "spawn alien|boss [name]",
+			run:   spawnCommand,
+			complete: func(c *Console, args []string) []string {
+				switch {
+				case len(args) <= 1:
+					return []string{"alien", "boss"}
+				case args[0] == "alien":
+					return alienNames(c.LoadedDesigns.ListOfAlienships)
+				case args[0] == "boss":
+					return alienNames(c.LoadedDesigns.ListOfBossShips)
+				}
+				return nil
+			},
+		},
+		"give": {
+			usage: "give modifier <name> | give kit",
+			run:   giveCommand,
+			complete: func(c *Console, args []string) []string {
+				if len(args) <= 1 {
+					return []string{"modifier", "kit"}
+				}
+				if args[0] == "modifier" {
+					var names []string
+					for _, m := range c.LoadedDesigns.ModifierDesign {
+						names = append(names, m.Name)
+					}
+					return names
+				}
+				return nil
+			},
+		},
+		"setlevel": {
+			usage: "setlevel <level>",
+			run:   setLevelCommand,
+		},
+		"god": {
+			usage: "god",
+			run: func(c *Console, gc *game.GameContext, args []string) string {
+				s, ok := gc.FindEntity("spaceship").(*SpaceShip)
+				if !ok {
+					return "no spaceship"
+				}
+				s.God = !s.God
+				return fmt.Sprintf("god mode: %t", s.God)
+			},
+		},
+		"killall": {
+			usage: "killall",
+			run:   killAllCommand,
+		},
+		"timescale": {
+			usage: "timescale <scale>",
+			run: func(c *Console, gc *game.GameContext, args []string) string {
+				if len(args) == 0 {
+					return fmt.Sprintf("timescale: %.2f", gc.TimeScale)
+				}
+				scale, err := strconv.ParseFloat(args[0], 64)
+				if err != nil || scale <= 0 || scale > 10 {
+					return "timescale must be between 0 and 10"
+				}
+				gc.TimeScale = scale
+				return fmt.Sprintf("timescale: %.2f", scale)
+			},
+		},
+		"entities": {
+			usage: "entities",
+			run:   entitiesCommand,
+		},
+		"clear": {
+			usage: "clear",
+			run: func(c *Console, gc *game.GameContext, args []string) string {
+				c.output = nil
+				return ""
+			},
+		},
+	}
+	return c
+}
+
+func (c *Console) commandNames() []string {
+	names := make([]string, 0, len(c.commands))
+	for name := range c.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (c *Console) print(line string) {
+	if line == "" {
+		return
+	}
+	c.output = append(c.output, line)
+	if len(c.output) > consoleLines {
+		c.output = c.output[len(c.output)-consoleLines:]
+	}
+}
+
+func (c *Console) execute(line string, gc *game.GameContext) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return
+	}
+	c.print("> " + line)
+	c.history = append(c.history, line)
+	c.historyIndex = len(c.history)
+
+	cmd, ok := c.commands[strings.ToLower(fields[0])]
+	if !ok {
+		c.print(fmt.Sprintf("unknown command %q, try help", fields[0]))
+		return
+	}
+	game.Logger(game.SubsystemGame).Info("console command", "command", line)
+	c.print(cmd.run(c, gc, fields[1:]))
+}
+
+// complete completes the word being typed, names of the designs can have spaces so
+// everything after the sub command is completed as a single argument.
+func (c *Console) complete() {
+	text := string(c.input)
+	fields := strings.Fields(text)
+	typingNew := strings.HasSuffix(text, " ") || len(fields) == 0
+
+	var candidates []string
+	var prefix string
+	switch {
+	case len(fields) == 0 || (len(fields) == 1 && !typingNew):
+		candidates = c.commandNames()
+		if len(fields) == 1 {
+			prefix = fields[0]
+		}
+	default:
+		cmd, ok := c.commands[strings.ToLower(fields[0])]
+		if !ok || cmd.complete == nil {
+			return
+		}
+		args := fields[1:]
+		if typingNew {
+			args = append(args, "")
+		}
+		if len(args) <= 1 {
+			prefix = args[0]
+		} else {
+			// everything after the command and the sub command
+			rest := text
+			for _, f := range fields[:2] {
+				rest = strings.TrimLeft(rest, " ")[len(f):]
+			}
+			prefix = strings.TrimLeft(rest, " ")
+		}
+		candidates = cmd.complete(c, args)
+	}
+
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(strings.ToLower(candidate), strings.ToLower(prefix)) {
+			matches = append(matches, candidate)
+		}
+	}
+	if len(matches) == 0 {
+		return
+	}
+
+	completed := commonPrefix(matches)
+	if len(matches) == 1 {
+		completed += " "
+	} else {
+		c.print(strings.Join(matches, ", "))
+	}
+	if len([]rune(completed)) < len([]rune(prefix)) {
+		return
+	}
+	c.input = []rune(strings.TrimSuffix(text, prefix) + completed)
+}
+
+func commonPrefix(words []string) string {
+	// by rune, the names may not be ASCII
+	prefix := []rune(words[0])
+	for _, w := range words[1:] {
+		n := 0
+		for _, r := range w {
+			if n == len(prefix) || unicode.ToLower(r) != unicode.ToLower(prefix[n]) {
+				break
+			}
+			n++
+		}
+		prefix = prefix[:n]
+	}
+	return string(prefix)
+}
+
+// close pops the console only when it is the top scene, a scene opened over it (i.e a
+// level up from setlevel) stays until it is closed.
+func (c *Console) close(gc *game.GameContext) {
+	gc.Scenes.PopScene(gc, c.scene)
+}
+
+// InputEvents opens the console, the typing goes to consoleInput through its scene.
+func (c *Console) InputEvents(event tcell.Event, gc *game.GameContext) {
+	if ev, ok := event.(*tcell.EventKey); ok && ev.Rune() == '`' && !c.Open {
+		c.scene = c.consoleScene()
+		gc.Scenes.Push(gc, c.scene)
+	}
+}
+
+func (c *Console) consoleInput(event tcell.Event, gc *game.GameContext) {
+	ev, ok := event.(*tcell.EventKey)
+	if !ok {
+		return
+	}
+	if ev.Rune() == '`' {
+		c.close(gc)
+		return
+	}
+
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		c.close(gc)
+	case tcell.KeyEnter:
+		c.execute(string(c.input), gc)
+		c.input = nil
+	case tcell.KeyTab:
+		c.complete()
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if len(c.input) > 0 {
+			c.input = c.input[:len(c.input)-1]
+		}
+	case tcell.KeyUp:
+		if c.historyIndex > 0 {
+			c.historyIndex--
+			c.input = []rune(c.history[c.historyIndex])
+		}
+	case tcell.KeyDown:
+		if c.historyIndex < len(c.history)-1 {
+			c.historyIndex++
+			c.input = []rune(c.history[c.historyIndex])
+		} else {
+			c.historyIndex = len(c.history)
+			c.input = nil
+		}
+	case tcell.KeyRune:
+		c.input = append(c.input, ev.Rune())
+	}
+}
+
+func (c *Console) Update(gc *game.GameContext, delta float64) {}
+
+func (c *Console) Draw(gc *game.GameContext) {
+	if !c.Open {
+		return
+	}
+	w, _ := base.GetSize()
+	whiteColor := base.StyleIt(tcell.ColorWhite)
+	greenColor := base.StyleIt(tcell.ColorGreenYellow)
+
+	// wrap the long lines, only the last lines fit in the box
+	var lines []string
+	for _, line := range append(slices.Clone(c.output), "> "+string(c.input)+"_") {
+		runes := []rune(line)
+		width := max(w-4, 1) // wraps at least one character per line on tiny terminals
+		for len(runes) > width {
+			lines = append(lines, string(runes[:width]))
+			runes = runes[width:]
+		}
+		lines = append(lines, string(runes))
+	}
+	lines = lines[max(len(lines)-consoleLines-1, 0):]
+
+	ui.DrawBoxOverlap(base.Point{X: 0, Y: 0}, w, consoleLines+3, func(x, y int) {
+		start := consoleLines + 1 - len(lines)
+		for j, line := range lines {
+			for i, r := range line {
+				base.SetContentWithStyle(x+i+2, y+start+j, r, whiteColor)
+			}
+		}
+	}, greenColor)
+}
+
+func (c *Console) GetType() string {
+	return "console"
+}
+
+func (c *Console) GetLayer() game.Layer {
+	return game.LayerMenu
+}
+
+func (c *Console) GetPhase() game.Phase {
+	return game.PhaseInput
+}
+
+func alienNames(designs []design.AlienshipDesign) []string {
+	names := make([]string, 0, len(designs))
+	for _, d := range designs {
+		names = append(names, d.Name)
+	}
+	return names
+}
+
+func findAlien(designs []design.AlienshipDesign, name string) (design.AlienshipDesign, bool) {
+	for _, d := range designs {
+		if strings.EqualFold(d.Name, name) {
+			return d, true
+		}
+	}
+	return design.AlienshipDesign{}, false
+}
+
+func spawnCommand(c *Console, gc *game.GameContext, args []string) string {
+	if len(args) == 0 {
+		return c.commands["spawn"].usage
+	}
+	name := strings.Join(args[1:], " ")
+	switch args[0] {
+	case "alien":
+		a, ok := gc.FindEntity("alien").(*AlienProducer)
+		if !ok {
+			return "no alien producer"
+		}
+		designs := a.LoadedDesigns.ListOfAlienships
+		if name != "" {
+			d, ok := findAlien(designs, name)
+			if !ok {
+				return fmt.Sprintf("unknown alien %q", name)
+			}
+			designs = []design.AlienshipDesign{d}
+		}
+		alien := base.Deploy(designs, a.Level, a.Aliens...)
+		a.Aliens = append(a.Aliens, alien)
+		return fmt.Sprintf("spawned %s", alien.Name)
+	case "boss":
+		b, ok := gc.FindEntity("boss").(*BossProducer)
+		if !ok {
+			return "no boss producer"
+		}
+		if b.BossAlien != nil {
+			return fmt.Sprintf("%s is already deployed", b.BossAlien.Name)
+		}
+		designs := b.LoadedDesigns.ListOfBossShips
+		if name != "" {
+			d, ok := findAlien(designs, name)
+			if !ok {
+				return fmt.Sprintf("unknown boss %q", name)
+			}
+			designs = []design.AlienshipDesign{d}
+		}
+		b.BossAlien = base.Deploy(designs, b.Level)
+		game.Publish(gc, game.BossSpawned{Name: b.BossAlien.Name})
+		return fmt.Sprintf("spawned %s", b.BossAlien.Name)
+	}
+	return c.commands["spawn"].usage
+}
+
+func giveCommand(c *Console, gc *game.GameContext, args []string) string {
+	s, ok := gc.FindEntity("spaceship").(*SpaceShip)
+	if !ok || len(args) == 0 {
+		return c.commands["give"].usage
+	}
+	switch args[0] {
+	case "kit":
+		s.HealthKit.HealthKitsOwned = min(s.HealthKit.HealthKitsOwned+1, s.HealthKit.HealthKitLimit)
+		return fmt.Sprintf("health kits: %d", s.HealthKit.HealthKitsOwned)
+	case "modifier":
+		name := strings.Join(args[1:], " ")
+		for _, m := range c.LoadedDesigns.ModifierDesign {
+			if strings.EqualFold(m.Name, name) {
+				s.ApplyModifier(m, gc)
+				return fmt.Sprintf("applied %s", m.Name)
+			}
+		}
+		return fmt.Sprintf("unknown modifier %q", name)
+	}
+	return c.commands["give"].usage
+}
+
+func setLevelCommand(c *Console, gc *game.GameContext, args []string) string {
+	s, ok := gc.FindEntity("spaceship").(*SpaceShip)
+	if !ok || len(args) == 0 {
+		return c.commands["setlevel"].usage
+	}
+	level, err := strconv.Atoi(args[0])
+	if err != nil || level <= s.Level || level >= s.cfg.SpaceShipConfig.MaxLevel {
+		return fmt.Sprintf("level must be between %d and %d", s.Level+1, s.cfg.SpaceShipConfig.MaxLevel-1)
+	}
+	// the producers level up once per level, the last one goes through the level up menu
+	for l := s.Level + 1; l < level; l++ {
+		game.Publish(gc, game.LevelUp{Level: l})
+	}
+	s.Level = level
+	s.PreviousLevel = level - 1
+	s.NextLevelScore = s.cfg.SpaceShipConfig.NextLevelScore * (level + 1)
+	return fmt.Sprintf("level: %d", level)
+}
+
+func killAllCommand(c *Console, gc *game.GameContext, args []string) string {
+	killed := 0
+	if a, ok := gc.FindEntity("alien").(*AlienProducer); ok {
+		for _, alien := range a.Aliens {
+			alien.Health = 0
+			killed++
+		}
+	}
+	if b, ok := gc.FindEntity("boss").(*BossProducer); ok && b.BossAlien != nil {
+		b.BossAlien.Health = 0
+		killed++
+	}
+	if a, ok := gc.FindEntity("asteroid").(*AsteroidProducer); ok {
+		for _, asteroid := range a.Asteroids {
+			asteroid.Health = 0
+			killed++
+		}
+	}
+	return fmt.Sprintf("killed %d", killed)
+}
+
+func entitiesCommand(c *Console, gc *game.GameContext, args []string) string {
+	var parts []string
+	for _, e := range gc.GetEntities() {
+		part := e.GetType()
+		switch e := e.(type) {
+		case *AlienProducer:
+			part += fmt.Sprintf("(%d, level %.1f)", len(e.Aliens), e.Level)
+		case *AsteroidProducer:
+			part += fmt.Sprintf("(%d, level %.1f)", len(e.Asteroids), e.Level)
+		case *BossProducer:
+			if e.BossAlien != nil {
+				part += fmt.Sprintf("(%s %d/%d)", e.BossAlien.Name, e.BossAlien.Health, e.BossAlien.MaxHealth)
+			}
+		case *SpaceShip:
+			part += fmt.Sprintf("(HP %d/%d, level %d)", e.Health, e.MaxHealth, e.Level)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,160 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/omar0ali/spaceinvaders-game-cli/base"
+	"github.com/omar0ali/spaceinvaders-game-cli/entities/ui"
+	"github.com/omar0ali/spaceinvaders-game-cli/game"
+	"github.com/omar0ali/spaceinvaders-game-cli/game/design"
+)
+
+type ActiveEffect struct {
+	Modifier  design.ModifierDesign
+	Remaining float64 // seconds, counts down only while the game is running (not halted)
+	Stacks    int
+	reverts   []func()
+}
+
+type Effects struct {
+	Active []*ActiveEffect
+}
+
+func (e *Effects) HasEffect(effect design.ModifierEffect) bool {
+	for _, a := range e.Active {
+		if a.Modifier.Effect == effect {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Effects) find(name string) *ActiveEffect {
+	for _, a := range e.Active {
+		if a.Modifier.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// ApplyModifier applies the stat changes of the modifier, timed modifiers are tracked
+// and reverted when they expire.
+func (s *SpaceShip) ApplyModifier(m design.ModifierDesign, gc *game.GameContext) {
+	s.IncreaseHealth(m.ModifyHealth)
+
+	if m.Duration <= 0 {
+		s.applyGunModifier(m)
+		return
+	}
+
+	duration := float64(m.Duration) / 1000
+	active := s.Effects.find(m.Name)
+	if active == nil {
+		active = &ActiveEffect{Modifier: m}
+		s.Effects.Active = append(s.Effects.Active, active)
+	}
+
+	switch m.Stacking {
+	case design.Extend:
+		active.Remaining += duration
+		if active.Stacks == 0 {
+			active.reverts = append(active.reverts, s.applyGunModifier(m))
+			active.Stacks = 1
+		}
+	case design.Stack:
+		active.Remaining = duration
+		if active.Stacks < max(m.MaxStacks, 1) {
+			active.reverts = append(active.reverts, s.applyGunModifier(m))
+			active.Stacks++
+		}
+	default: // refresh
+		active.Remaining = duration
+		if active.Stacks == 0 {
+			active.reverts = append(active.reverts, s.applyGunModifier(m))
+			active.Stacks = 1
+		}
+	}
+}
+
+// applyGunModifier returns a function to revert exactly what was changed, since the
+// gun limits might have applied only part of the modifier.
+func (s *SpaceShip) applyGunModifier(m design.ModifierDesign) func() {
+	gun := s.GunFor(m.Weapon)
+	snapshot := gun.GetStats()
+
+	gun.IncreaseGunCap(m.ModifyGunCap, m.MaxValue)
+	gun.IncreaseGunPower(m.ModifyGunPower)
+	gun.IncreaseGunSpeed(m.ModifyGunSpeed, m.MaxValue)
+	gun.DecreaseCooldown(m.ModifyGunCoolDown)
+	gun.DecreaseGunReloadCooldown(m.ModifyGunReloadCoolDown)
+
+	diff := gun.Diff(snapshot)
+	return func() {
+		gun.Revert(diff)
+	}
+}
+
+func (s *SpaceShip) UpdateEffects(delta float64, gc *game.GameContext) {
+	active := s.Effects.Active[:0]
+	for _, a := range s.Effects.Active {
+		a.Remaining -= delta
+		if a.Remaining > 0 {
+			active = append(active, a)
+			continue
+		}
+		for _, revert := range a.reverts {
+			revert()
+		}
+		SetStatus(fmt.Sprintf("%s Expired", a.Modifier.Name), gc)
+	}
+	s.Effects.Active = active
+}
+
+// UIEffectsData displays the active timed effects next to the spaceship details.
+func (s *SpaceShip) UIEffectsData(gc *game.GameContext) {
+	if len(s.Effects.Active) == 0 {
+		return
+	}
+
+	whiteColor := base.StyleIt(tcell.ColorWhite)
+	greenColor := base.StyleIt(tcell.ColorGreenYellow)
+
+	var lines []string
+	width := 0
+	for _, a := range s.Effects.Active {
+		line := fmt.Sprintf("%s %2.fs", a.Modifier.Name, math.Ceil(a.Remaining))
+		if a.Stacks > 1 {
+			line += fmt.Sprintf(" x%d", a.Stacks)
+		}
+		lines = append(lines, line)
+		width = max(width, len([]rune(line)))
+	}
+
+	_, h := base.GetSize()
+	ui.DrawBoxOverlap(base.Point{X: 23, Y: h - 4 - len(lines)}, width+4, len(lines)+2, func(x, y int) {
+		for j, line := range lines {
+			for i, r := range line {
+				base.SetContentWithStyle(x+i+2, y+j+1, r, whiteColor)
+			}
+		}
+	}, greenColor)
+}
+
+// EnemyTimeScale is how fast the enemies move and fire, halved while the slow motion
+// effect is active.
+func EnemyTimeScale(gc *game.GameContext) float64 {
+	if s, ok := gc.FindEntity("spaceship").(*SpaceShip); ok {
+		if s.HasEffect(design.SlowMotion) {
+			return 0.5
+		}
+	}
+	return 1
+}
+
+// EnemyDelta slows down the enemies while the slow motion effect is active.
+func EnemyDelta(gc *game.GameContext, delta float64) float64 {
+	return delta * EnemyTimeScale(gc)
+}
@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/omar0ali/spaceinvaders-game-cli/base"
+	"github.com/omar0ali/spaceinvaders-game-cli/game"
+)
+
+// GameOverScreen shows the run report, it belongs to the game over scene.
+type GameOverScreen struct {
+	ui     *UI
+	scroll int // first line of the run report
+}
+
+// report returns the visible part of the run report, scrolled with the arrow keys.
+func (g *GameOverScreen) report(s *SpaceShip, gc *game.GameContext) string {
+	lines := []string{"Taken damage from:"}
+	lines = append(lines, s.GetRegisteredHits()...)
+	lines = append(lines,
+		"",
+		"Killed By:",
+		fmt.Sprintf("%s Level: %d", s.KilledBy.Name, s.KilledBy.Power),
+		"",
+		fmt.Sprintf("Credits Earned: +%d (Total: %d)", s.CreditsEarned, gc.Profile.Credits),
+		"",
+		"---------------- Run Report ----------------",
+	)
+	lines = append(lines, s.Stats.GetReport()...)
+
+	_, h := base.GetSize()
+	visible := max(h-14, 5)
+	g.scroll = max(min(g.scroll, len(lines)-visible), 0)
+	end := min(g.scroll+visible, len(lines))
+
+	footer := []string{
+		"---------------------------------------",
+		fmt.Sprintf("[Up/Down] Scroll (%d-%d of %d) [X] Export Report", g.scroll+1, end, len(lines)),
+		"Thank you for playing :) Would you like to play again?",
+		"[Ctrl+R] To Restart. [Ctrl+Q] To Quit.",
+	}
+	return strings.Join(append(lines[g.scroll:end], footer...), "\n")
+}
+
+func (g *GameOverScreen) Update(gc *game.GameContext, delta float64) {}
+
+func (g *GameOverScreen) Draw(gc *game.GameContext) {
+	if s, ok := gc.FindEntity("spaceship").(*SpaceShip); ok {
+		g.ui.MessageBox(base.GetCenterPoint(), g.report(s, gc), "Game Over")
+	}
+}
+
+func (g *GameOverScreen) InputEvents(events tcell.Event, gc *game.GameContext) {
+	switch ev := events.(type) {
+	case *tcell.EventKey:
+		switch ev.Key() {
+		case tcell.KeyUp:
+			g.scroll--
+		case tcell.KeyDown:
+			g.scroll++
+		case tcell.KeyPgUp:
+			g.scroll -= 10
+		case tcell.KeyPgDn:
+			g.scroll += 10
+		}
+		if ev.Rune() == 'x' || ev.Rune() == 'X' {
+			if s, ok := gc.FindEntity("spaceship").(*SpaceShip); ok {
+				name, err := s.Stats.Export()
+				if err != nil {
+					game.Logger(game.SubsystemUI).Error("failed to export the report", "err", err)
+					SetStatus("Failed to export the report", gc)
+					return
+				}
+				SetStatus(fmt.Sprintf("Report saved: %s.json/.csv", filepath.Base(name)), gc)
+			}
+		}
+	case *tcell.EventMouse:
+		switch ev.Buttons() {
+		case tcell.WheelUp:
+			g.scroll--
+		case tcell.WheelDown:
+			g.scroll++
+		}
+	}
+	g.scroll = max(g.scroll, 0) // the upper bound is checked when drawing
+}
+
+func (g *GameOverScreen) GetType() string {
+	return "game_over"
+}
+
+func (g *GameOverScreen) GetLayer() game.Layer {
+	return game.LayerMenu
+}
+
+func (g *GameOverScreen) GetPhase() game.Phase {
+	return game.PhaseInput
+}
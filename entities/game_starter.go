@@ -10,7 +10,7 @@ import (
 func StartGame(gc *game.GameContext, cfg game.GameConfig, exitCha chan struct{}) {
 	// loading designs
 	loadedUIDesigns := design.LoadDesigns()
-	// order is important since some objects might overlap others
+	// entities are drawn on their layer (GetLayer), in this order within the same layer
 	gc.AddEntity(NewStarsProducer(cfg))
 	gc.AddEntity(NewSpaceShip(cfg, gc, loadedUIDesigns))
 	gc.AddEntity(NewModifierProducer(gc, loadedUIDesigns))
@@ -19,12 +19,29 @@ func StartGame(gc *game.GameContext, cfg game.GameConfig, exitCha chan struct{})
 	}
 	gc.AddEntity(NewAlienProducer(gc, loadedUIDesigns))
 	gc.AddEntity(NewBossAlienProducer(gc, loadedUIDesigns))
-	gc.AddEntity(particles.NewParticleSystem())
+	gc.AddEntity(particles.NewParticleSystem(loadedUIDesigns.ListOfEffects))
+	gc.AddEntity(NewMusicDirector())
+	gc.AddEntity(NewPostFX(cfg, gc))
 	gc.AddEntity(ui.NewUISystem())
-	gc.AddEntity(NewUI(gc, cfg, exitCha))
+	u := NewUI(cfg, exitCha)
+	gc.AddEntity(u)
+	if cfg.Dev.Profiler {
+		gc.AddEntity(NewProfiler(cfg))
+	}
+	if cfg.Dev.Console {
+		gc.AddEntity(NewConsole(loadedUIDesigns))
+	}
+	gc.Scenes.Push(gc, u.mainMenuScene())
 }
 
 func RestartGame(gc *game.GameContext, cfg game.GameConfig, exitCha chan struct{}) {
+	if err := gc.Profile.Save(); err != nil { // keeps the achievement counters of the run
+		game.Logger(game.SubsystemGame).Error("failed to save profile", "err", err)
+	}
 	gc.RemoveAllEntities()
+	gc.Events.Reset()
+	gc.Scenes.Reset()
+	gc.TimeScale = 1
+	gc.FreezeTime = 0
 	StartGame(gc, cfg, exitCha)
 }
@@ -0,0 +1,136 @@
+package entities
+
+import (
+	"fmt"
+
+	"github.com/omar0ali/spaceinvaders-game-cli/entities/ui"
+	"github.com/omar0ali/spaceinvaders-game-cli/game"
+	"github.com/omar0ali/spaceinvaders-game-cli/game/design"
+)
+
+// ApplyUpgrades applies the permanent upgrades bought in the hangar, called when a run starts.
+func (s *SpaceShip) ApplyUpgrades(profile *game.Profile) {
+	for _, upgrade := range s.LoadedDesigns.ListOfUpgrades {
+		for range profile.Upgrades[upgrade.ID] {
+			if upgrade.HealthKits != 0 {
+				s.HealthKit.HealthKitsOwned = min(s.HealthKit.HealthKitsOwned+upgrade.HealthKits, s.HealthKit.HealthKitLimit)
+			}
+			s.ApplyAbility(upgrade.Effect, upgrade.Effect.MaxValue)
+		}
+	}
+}
+
+// rewardRun adds the credits earned in this run to the profile, called on game over.
+func (s *SpaceShip) rewardRun(gc *game.GameContext) {
+	if gc.Profile == nil {
+		return
+	}
+	s.CreditsEarned = gc.Profile.AwardRun(s.TotalScore, s.Kills)
+}
+
+func upgradeDesc(upgrade design.UpgradeDesign, rank, credits int) []string {
+	desc := []string{
+		fmt.Sprintf("- [%s] Rank %d/%d", upgrade.Name, rank, upgrade.MaxRank),
+		fmt.Sprintf("* Description: %s", upgrade.Description),
+	}
+	if rank >= upgrade.MaxRank {
+		desc = append(desc, "* Maxed out.")
+	} else {
+		desc = append(desc, fmt.Sprintf("* Cost:        %d credits", upgrade.GetCost(rank)))
+	}
+	return append(desc, fmt.Sprintf("* Credits:     %d", credits))
+}
+
+// HangarMenu spends the credits earned in previous runs on spaceships and permanent upgrades.
+// back returns to the menu it was opened from.
+func (u *UI) HangarMenu(gc *game.GameContext, layout *ui.UISystem, designs *design.LoadedDesigns, back func()) {
+	profile := gc.Profile
+	layoutHangarMenu := ui.InitCodexMenu(20, 5)
+	layoutHangarMenu.SelectedDesc = []string{fmt.Sprintf("Hangar - Credits: %d", profile.Credits)}
+
+	var shipItems, upgradeItems func() []*ui.Box
+	shipItems = func() []*ui.Box {
+		var boxes []*ui.Box
+		for i, shipDesign := range designs.ListOfSpaceships {
+			desc := []string{
+				fmt.Sprintf("- [%s]", shipDesign.Name),
+				fmt.Sprintf("* HP:         %d", shipDesign.EntityHealth),
+				fmt.Sprintf("* Gun POW:    %d", shipDesign.GunPower),
+				fmt.Sprintf("* Gun CAP:    %d", shipDesign.GunCap),
+			}
+			if profile.IsUnlocked(shipDesign.Name, shipDesign.UnlockCost) {
+				desc = append(desc, "* Unlocked.")
+			} else {
+				desc = append(desc, fmt.Sprintf("* Unlock:     %d credits", shipDesign.UnlockCost))
+			}
+			desc = append(desc, fmt.Sprintf("* Credits:    %d", profile.Credits))
+
+			boxes = append(boxes, ui.NewUIBox(shipDesign.Shape, desc, func() {
+				if profile.IsUnlocked(shipDesign.Name, shipDesign.UnlockCost) {
+					return
+				}
+				if !profile.UnlockShip(shipDesign.Name, shipDesign.UnlockCost) {
+					SetStatus("Not enough credits!", gc)
+					return
+				}
+				SetStatus(fmt.Sprintf("%s Unlocked", shipDesign.Name), gc)
+				items := shipItems()
+				layoutHangarMenu.SetList(items)
+				layoutHangarMenu.SelectedDesc = items[i].Description
+			}))
+		}
+		return boxes
+	}
+	upgradeItems = func() []*ui.Box {
+		var boxes []*ui.Box
+		for _, upgrade := range designs.ListOfUpgrades {
+			rank := profile.Upgrades[upgrade.ID]
+			shape := []string{upgrade.Name, fmt.Sprintf("%d/%d", rank, upgrade.MaxRank)}
+
+			boxes = append(boxes, ui.NewUIBox(shape, upgradeDesc(upgrade, rank, profile.Credits), func() {
+				rank := profile.Upgrades[upgrade.ID]
+				if rank >= upgrade.MaxRank {
+					SetStatus(fmt.Sprintf("%s Maxed Out!", upgrade.Name), gc)
+					return
+				}
+				if !profile.BuyUpgrade(upgrade.ID, upgrade.GetCost(rank)) {
+					SetStatus("Not enough credits!", gc)
+					return
+				}
+				SetStatus(fmt.Sprintf("%s Rank %d", upgrade.Name, rank+1), gc)
+				layoutHangarMenu.SetList(upgradeItems())
+				layoutHangarMenu.SelectedDesc = upgradeDesc(upgrade, rank+1, profile.Credits)
+			}))
+		}
+		return boxes
+	}
+
+	layoutHangarMenu.SetMenuItems([]*ui.Box{
+		ui.NewUIBox(
+			[]string{
+				"Spaceships",
+			},
+			[]string{
+				"Unlock new spaceships.",
+			}, func() {
+				layoutHangarMenu.SetList(shipItems())
+			}),
+		ui.NewUIBox(
+			[]string{
+				"Upgrades",
+			},
+			[]string{
+				"Permanent upgrades applied at the start of every run.",
+			}, func() {
+				layoutHangarMenu.SetList(upgradeItems())
+			}),
+		ui.NewUIBox(
+			[]string{
+				"< Back",
+			},
+			[]string{
+				"Back to the menu.",
+			}, back),
+	})
+	layout.SetLayout(layoutHangarMenu)
+}
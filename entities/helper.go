@@ -28,6 +28,33 @@ type Movable interface {
 	GetSpeed() float64
 }
 
+type Shieldable interface {
+	IsShieldUp() bool
+}
+
+type Flashable interface {
+	Flash()
+}
+
+// emitterOf is the centre of the object, sounds played from it are panned to where it is.
+func emitterOf(o interface {
+	Sizeable
+	PointableFloat
+}) game.Emitter {
+	return game.Emitter{
+		X: o.GetPosition().X + float64(o.GetWidth())/2,
+		Y: o.GetPosition().Y + float64(o.GetHeight())/2,
+	}
+}
+
+// rescale moves the point to the same relative position in the resized playfield.
+func rescale(p *base.PointFloat, e game.Resized) {
+	if e.PrevWidth > 0 && e.PrevHeight > 0 {
+		p.X *= float64(e.Width) / float64(e.PrevWidth)
+		p.Y *= float64(e.Height) / float64(e.PrevHeight)
+	}
+}
+
 func Move(m Movable, delta float64) {
 	distance := m.GetSpeed() * delta
 	m.AppendPositionY(distance)
@@ -72,26 +99,41 @@ func GettingHit(m Movable, beam PointableInt, gc *game.GameContext) bool {
 
 	if px >= ox && px < ox+m.GetWidth() &&
 		py >= oy && py < oy+m.GetHeight() {
+		if f, ok := m.(Flashable); ok {
+			if fx, ok := gc.FindEntity("postfx").(*PostFX); ok {
+				fx.HitFlash(f)
+			}
+		}
+
+		if s, ok := m.(Shieldable); ok && s.IsShieldUp() {
+			ShieldHit(float64(beam.GetPosition().X), float64(beam.GetPosition().Y), gc)
+			return true
+		}
 
 		if p, ok := gc.FindEntity("particles").(*particles.ParticleSystem); ok {
-			p.AddParticles(
-				particles.InitExplosion(3,
-					particles.WithDimensions(
-						float64(beam.GetPosition().X),
-						float64(beam.GetPosition().Y),
-						0,
-						0,
-					),
-					particles.WithSymbols([]rune("Oo;.")),
-				),
-			)
-			gc.Sounds.PlaySound("8-bit-explosion.mp3", 0)
+			effect := "hit"
+			if b, ok := beam.(*base.Beam); ok && b.Effect != "" {
+				effect = b.Effect
+			}
+			p.Emit(effect, float64(beam.GetPosition().X), float64(beam.GetPosition().Y), 0, 0)
+			gc.Sounds.PlaySound("beam_hit", game.Emitter{
+				X: float64(beam.GetPosition().X),
+				Y: float64(beam.GetPosition().Y),
+			})
 		}
 		return true
 	}
 	return false
 }
 
+// ShieldHit is the feedback when a shield absorbs the hit instead of the hull.
+func ShieldHit(x, y float64, gc *game.GameContext) {
+	if p, ok := gc.FindEntity("particles").(*particles.ParticleSystem); ok {
+		p.Emit("shield_hit", x, y, 0, 0)
+	}
+	gc.Sounds.PlaySound("shield_hit", game.Emitter{X: x, Y: y})
+}
+
 func Crash(c1, c2 Movable, gc *game.GameContext) bool {
 	x1 := int(math.Round(c1.GetPosition().GetX()))
 	y1 := int(math.Round(c1.GetPosition().GetY()))
@@ -109,29 +151,9 @@ func Crash(c1, c2 Movable, gc *game.GameContext) bool {
 		y1+h1 > y2 {
 
 		if p, ok := gc.FindEntity("particles").(*particles.ParticleSystem); ok {
-			p.AddParticles(
-				particles.InitExplosion(3,
-					particles.WithDimensions(
-						c1.GetPosition().X,
-						c1.GetPosition().Y,
-						c1.GetWidth(),
-						c1.GetHeight(),
-					),
-					particles.WithSymbols([]rune(".oO0*;.")),
-				),
-			)
-			p.AddParticles(
-				particles.InitExplosion(3,
-					particles.WithDimensions(
-						c2.GetPosition().X,
-						c2.GetPosition().Y,
-						c2.GetWidth(),
-						c2.GetHeight(),
-					),
-					particles.WithSymbols([]rune(".oO0*;.")),
-				),
-			)
-			gc.Sounds.PlaySound("8-bit-explosion-1.mp3", -2)
+			p.Emit("crash", c1.GetPosition().X, c1.GetPosition().Y, c1.GetWidth(), c1.GetHeight())
+			p.Emit("crash", c2.GetPosition().X, c2.GetPosition().Y, c2.GetWidth(), c2.GetHeight())
+			gc.Sounds.PlaySound("crash", emitterOf(c2))
 		}
 
 		return true
@@ -25,11 +25,16 @@ func NewModifierProducer(gc *game.GameContext, design *design.LoadedDesigns) *Mo
 		LoadedDesigns: design,
 	}
 
-	if spaceship, ok := gc.FindEntity("spaceship").(*SpaceShip); ok {
-		spaceship.OnLevelUp = append(spaceship.OnLevelUp, func(newLevel int) {
-			p.Level += 0.5
-		})
-	}
+	game.Subscribe(gc, func(e game.LevelUp) {
+		p.Level += 0.5
+	})
+	game.Subscribe(gc, func(e game.Resized) {
+		for _, d := range []*base.DropDown{p.Modifiers, p.HealthKit} {
+			if d != nil {
+				rescale(&d.Position, e)
+			}
+		}
+	})
 	return p
 }
 
@@ -87,15 +92,7 @@ func (p *ModifierProducer) Draw(gc *game.GameContext) {
 				X: int(p.HealthKit.Position.GetX()),
 				Y: int(p.HealthKit.Position.GetY()),
 			}, width, height, func(innerX, innerY int) {
-				for rowIndex, line := range p.HealthKit.Design.GetShape() {
-					for colIndex, char := range line {
-						if char != ' ' {
-							x := innerX + colIndex
-							y := innerY + rowIndex
-							base.SetContentWithStyle(x, y, char, color)
-						}
-					}
-				}
+				base.DrawSprite(innerX, innerY, p.HealthKit.Flashed(p.HealthKit.Design))
 			}, color)
 		p.HealthKit.DisplayHealth(11, color, nil)
 	}
@@ -109,15 +106,7 @@ func (p *ModifierProducer) Draw(gc *game.GameContext) {
 				X: int(p.Modifiers.Position.GetX()),
 				Y: int(p.Modifiers.Position.GetY()),
 			}, width, height, func(innerX, innerY int) {
-				for rowIndex, line := range p.Modifiers.Design.GetShape() {
-					for colIndex, char := range line {
-						if char != ' ' {
-							x := innerX + colIndex
-							y := innerY + rowIndex
-							base.SetContentWithStyle(x, y, char, color)
-						}
-					}
-				}
+				base.DrawSprite(innerX, innerY, p.Modifiers.Flashed(p.Modifiers.Design))
 			}, color)
 		p.Modifiers.DisplayHealth(13, color, nil)
 	}
@@ -132,10 +121,10 @@ func (p *ModifierProducer) MovementAndCollision(delta float64, gc *game.GameCont
 	if p.HealthKit != nil {
 		Move(&p.HealthKit.ObjectBase, delta)
 		for _, beam := range spaceship.GetBeams() {
-			if GettingHit(&p.HealthKit.ObjectBase, beam, gc) {
+			if !beam.HasStruck(p.HealthKit) && GettingHit(&p.HealthKit.ObjectBase, beam, gc) {
 				p.SelectedDropDown = p.HealthKit
-				p.HealthKit.TakeDamage(spaceship.GetPower())
-				spaceship.RemoveBeam(beam)
+				p.HealthKit.TakeDamage(beam.Damage())
+				spaceship.Spend(beam, p.HealthKit)
 			}
 		}
 
@@ -148,7 +137,7 @@ func (p *ModifierProducer) MovementAndCollision(delta float64, gc *game.GameCont
 					return
 				}
 				spaceship.HealthKit.HealthKitsOwned += 1
-				spaceship.ScoreHit()
+				game.Publish(gc, game.PickupCollected{Name: p.LoadedDesigns.HealthKitDesign.Name})
 				SetStatus("Health kit +1", gc)
 			}
 			if p.SelectedDropDown == p.HealthKit {
@@ -161,29 +150,21 @@ func (p *ModifierProducer) MovementAndCollision(delta float64, gc *game.GameCont
 	if p.Modifiers != nil {
 		Move(&p.Modifiers.ObjectBase, delta)
 		for _, beam := range spaceship.GetBeams() {
-			if GettingHit(&p.Modifiers.ObjectBase, beam, gc) {
+			if !beam.HasStruck(p.Modifiers) && GettingHit(&p.Modifiers.ObjectBase, beam, gc) {
 				p.SelectedDropDown = p.Modifiers
-				p.Modifiers.TakeDamage(spaceship.GetPower())
-				spaceship.RemoveBeam(beam)
+				p.Modifiers.TakeDamage(beam.Damage())
+				spaceship.Spend(beam, p.Modifiers)
 			}
 		}
 
 		p.Modifiers.MovementAndColision(delta, func(isDead bool) {
 			if isDead {
-				spaceship.ScoreHit()
 				if m, ok := p.Modifiers.Design.(*design.ModifierDesign); ok {
-					spaceship.IncreaseHealth(m.ModifyHealth)
-					spaceship.IncreaseGunCap(m.ModifyGunCap, m.MaxValue)
-					spaceship.IncreaseGunPower(m.ModifyGunPower)
-					spaceship.IncreaseGunSpeed(m.ModifyGunSpeed, m.MaxValue)
-					spaceship.DecreaseCooldown(m.ModifyGunCoolDown)
-					spaceship.DecreaseGunReloadCooldown(m.ModifyGunReloadCoolDown)
+					game.Publish(gc, game.PickupCollected{Name: m.Name})
+					spaceship.ApplyModifier(*m, gc)
 					if m.ModifyLevel {
 						SetStatus("Free Level Up!", gc)
-						if u, ok := gc.FindEntity("ui").(*UI); ok {
-							u.LevelUpScreen = true
-							spaceship.LevelUpMenu(gc)
-						}
+						spaceship.LevelUpMenu(gc)
 					} else {
 						SetStatus(fmt.Sprintf("Modifier %s Applied!", m.Name), gc)
 					}
@@ -197,17 +178,16 @@ func (p *ModifierProducer) MovementAndCollision(delta float64, gc *game.GameCont
 	}
 }
 
-func (p *ModifierProducer) InputEvents(event tcell.Event, gc *game.GameContext) {
-	// This code used for testing
-
-	// switch ev := event.(type) {
-	// case *tcell.EventKey:
-	// 	if ev.Rune() == 'y' {
-	// 		p.Modifiers = base.DeployDropDown(&p.LoadedDesigns.HealthKitDesign, int(p.Level))
-	// 	}
-	// }
-}
+func (p *ModifierProducer) InputEvents(event tcell.Event, gc *game.GameContext) {}
 
 func (p *ModifierProducer) GetType() string {
 	return "producer"
 }
+
+func (p *ModifierProducer) GetLayer() game.Layer {
+	return game.LayerActors
+}
+
+func (p *ModifierProducer) GetPhase() game.Phase {
+	return game.PhaseCollide
+}
@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/omar0ali/spaceinvaders-game-cli/game"
+)
+
+// MusicDirector picks the theme from the scenes and the boss, and the intensity of the
+// music from the number of enemies on screen.
+type MusicDirector struct {
+	theme   game.Theme
+	enemies int
+}
+
+func NewMusicDirector() *MusicDirector {
+	return &MusicDirector{enemies: -1}
+}
+
+func (m *MusicDirector) Update(gc *game.GameContext, delta float64) {
+	theme := game.ThemeMenu
+	switch {
+	case gc.Scenes.Has(game.SceneGameOver):
+		theme = game.ThemeNone
+	case gc.Scenes.Has(game.ScenePlaying):
+		theme = game.ThemeGameplay
+		if b, ok := gc.FindEntity("boss").(*BossProducer); ok && b.BossAlien != nil {
+			theme = game.ThemeBoss
+		}
+	}
+	if theme != m.theme {
+		m.theme = theme
+		gc.Sounds.PlayMusic(theme)
+	}
+
+	enemies := 0
+	if a, ok := gc.FindEntity("alien").(*AlienProducer); ok {
+		enemies += len(a.Aliens)
+	}
+	if a, ok := gc.FindEntity("asteroid").(*AsteroidProducer); ok {
+		enemies += len(a.Asteroids)
+	}
+	if enemies != m.enemies {
+		m.enemies = enemies
+		gc.Sounds.SetIntensity(enemies)
+	}
+}
+
+func (m *MusicDirector) Draw(gc *game.GameContext) {}
+
+func (m *MusicDirector) InputEvents(event tcell.Event, gc *game.GameContext) {}
+
+func (m *MusicDirector) GetType() string {
+	return "music"
+}
+
+func (m *MusicDirector) GetLayer() game.Layer {
+	return game.LayerBackground
+}
+
+func (m *MusicDirector) GetPhase() game.Phase {
+	return game.PhaseRender
+}
@@ -0,0 +1,120 @@
+package particles
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/omar0ali/spaceinvaders-game-cli/base"
+	"github.com/omar0ali/spaceinvaders-game-cli/game"
+	"github.com/omar0ali/spaceinvaders-game-cli/game/design"
+)
+
+// Emitter spawns the particles of an effect from effects.json over an object, all at
+// once (count) and/or at a rate for a while.
+type Emitter struct {
+	Particles []*Particle
+	design    *design.EffectDesign
+	x, y      float64
+	w, h      int
+	elapsed   float64 // seconds since the emitter started
+	owed      float64 // the fraction of a particle carried to the next frame at the rate
+}
+
+func NewEmitter(d *design.EffectDesign, x, y float64, width, height int) *Emitter {
+	e := &Emitter{
+		design: d,
+		x:      x,
+		y:      y,
+		w:      width,
+		h:      height,
+	}
+	e.spawn(d.Count)
+	return e
+}
+
+func (e *Emitter) GetTotalParticles() int {
+	return len(e.Particles)
+}
+
+func (e *Emitter) GetParticles() []*Particle {
+	return e.Particles
+}
+
+func (e *Emitter) RemoveParticle(particle *Particle) {
+	for i, p := range e.Particles {
+		if particle == p {
+			e.Particles = append(e.Particles[:i], e.Particles[i+1:]...)
+			break
+		}
+	}
+}
+
+// Emitting reports whether the emitter still spawns particles at its rate.
+func (e *Emitter) Emitting() bool {
+	return e.design.Rate > 0 && e.elapsed*1000 < float64(e.design.Duration)
+}
+
+func (e *Emitter) spawn(n int) {
+	d := e.design
+	for range n {
+		x, y := e.x+float64(e.w)/2, e.y+float64(e.h)/2
+		if d.Area {
+			x, y = e.x+rand.Float64()*float64(e.w), e.y+rand.Float64()*float64(e.h)
+		}
+		angle := (d.Angle + (rand.Float64()-0.5)*d.Spread) * math.Pi / 180
+		speed := d.Speed + rand.Float64()*d.SpeedJitter
+		e.Particles = append(e.Particles, &Particle{
+			ObjectEntity: base.ObjectEntity{
+				Position: base.PointFloat{X: x, Y: y},
+				Speed:    speed,
+			},
+			// the rows go down, so up is a negative Y
+			Velocity: base.PointFloat{X: math.Cos(angle) * speed, Y: -math.Sin(angle) * speed},
+			Lifetime: float64(d.Lifetime+rand.Intn(d.LifeJitter+1)) / 1000,
+		})
+	}
+}
+
+func (e *Emitter) Update(gc *game.GameContext, delta float64) {
+	if e.Emitting() {
+		e.owed += float64(e.design.Rate) * delta
+		n := int(e.owed)
+		e.owed -= float64(n)
+		e.spawn(n)
+	}
+	e.elapsed += delta
+
+	drag := max(0, 1-e.design.Drag*delta)
+	w, h := base.GetSize()
+	activeParticles := e.Particles[:0]
+	for _, p := range e.Particles {
+		p.Age += delta
+		if p.Age >= p.Lifetime {
+			continue
+		}
+		p.Velocity.Y += e.design.Gravity * delta
+		p.Velocity.X *= drag
+		p.Velocity.Y *= drag
+		p.Position.X += p.Velocity.X * delta
+		p.Position.Y += p.Velocity.Y * delta
+
+		// the particles that left the screen are gone for good (i.e long lived debris)
+		if int(p.Position.X) >= 0 && int(p.Position.X) < w &&
+			int(p.Position.Y) >= 0 && int(p.Position.Y) < h {
+			activeParticles = append(activeParticles, p)
+		}
+	}
+	e.Particles = activeParticles
+}
+
+func (e *Emitter) Draw(gc *game.GameContext) {
+	for _, p := range e.Particles {
+		t := p.Age / p.Lifetime
+		base.SetContentWithStyle(
+			int(p.Position.X),
+			int(p.Position.Y),
+			e.design.SymbolAt(t),
+			base.StyleIt(e.design.ColorAt(t)),
+		)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/omar0ali/spaceinvaders-game-cli/base"
 	"github.com/omar0ali/spaceinvaders-game-cli/game"
+	"github.com/omar0ali/spaceinvaders-game-cli/game/design"
 )
 
 type ParticleProducable interface {
@@ -15,61 +16,56 @@ type ParticleProducable interface {
 	RemoveParticle(particle *Particle)
 }
 
+// emitting producers are kept while they spawn particles, even without any left
+type emitting interface {
+	Emitting() bool
+}
+
 type Particle struct {
 	base.ObjectEntity
-	Symbol    []rune
-	Direction Direction
-	Style     tcell.Style
+	Velocity      base.PointFloat // cells per second
+	Age, Lifetime float64         // seconds
 }
 
 type ParticleSystem struct {
 	ParticleProducable []ParticleProducable
+	effects            map[string]*design.EffectDesign // from effects.json by name
 }
 
-type Direction = int
-
-const (
-	Up Direction = iota
-	Down
-	Left
-	Right
-	UpRight
-	UpLeft
-	DownLeft
-	DownRight
-)
-
-type ParticleOption func(*Particle)
-
-func WithDimensions(x, y float64, width, height int) ParticleOption {
-	return func(p *Particle) {
-		centerX := int(x) + width/2
-		centerY := int(y) + height/2
-
-		p.Position = base.PointFloat{X: float64(centerX), Y: float64(centerY)}
+func NewParticleSystem(effects []design.EffectDesign) *ParticleSystem {
+	ps := &ParticleSystem{effects: make(map[string]*design.EffectDesign, len(effects))}
+	for i := range effects {
+		ps.effects[effects[i].Name] = &effects[i]
 	}
+	return ps
 }
 
-func WithSpeed(initialSpeed int) ParticleOption {
-	return func(p *Particle) {
-		p.Speed = float64(initialSpeed)
+// Emit starts the effect from effects.json over an object at x, y of the given size
+// (0 for a point), unknown effects are logged and skipped. The tint (hex) replaces the
+// first colour of the effect, i.e the colour of the bomb that was dropped.
+func (ps *ParticleSystem) Emit(name string, x, y float64, width, height int, tint ...string) {
+	d, ok := ps.effects[name]
+	if !ok {
+		game.Logger(game.SubsystemGame).Warn("unknown particle effect", "name", name)
+		return
 	}
-}
-
-func WithSymbols(symbols []rune) ParticleOption {
-	return func(p *Particle) {
-		p.Symbol = symbols
+	if len(tint) > 0 && tint[0] != "" && len(d.Colors) > 0 {
+		tinted := *d
+		tinted.Colors = append([]string{tint[0]}, d.Colors[1:]...)
+		d = &tinted
 	}
+	ps.AddParticles(NewEmitter(d, x, y, width, height))
 }
 
-func WithStyle(style tcell.Style) ParticleOption {
-	return func(p *Particle) {
-		p.Style = style
+// Colliding returns the producers whose particles crash into the ships (i.e debris).
+func (ps *ParticleSystem) Colliding() []ParticleProducable {
+	var colliding []ParticleProducable
+	for _, p := range ps.ParticleProducable {
+		if e, ok := p.(*Emitter); ok && e.design.Collides {
+			colliding = append(colliding, p)
+		}
 	}
-}
-
-func NewParticleSystem() *ParticleSystem {
-	return &ParticleSystem{}
+	return colliding
 }
 
 func (ps *ParticleSystem) AddParticles(particleProducable ParticleProducable) {
@@ -80,7 +76,7 @@ func (ps *ParticleSystem) Update(gc *game.GameContext, delta float64) {
 	activeProducers := ps.ParticleProducable[:0]
 	for _, p := range ps.ParticleProducable {
 		p.Update(gc, delta)
-		if p.GetTotalParticles() > 0 {
+		if e, ok := p.(emitting); p.GetTotalParticles() > 0 || ok && e.Emitting() {
 			activeProducers = append(activeProducers, p)
 		}
 	}
@@ -98,3 +94,11 @@ func (ps *ParticleSystem) InputEvents(event tcell.Event, gc *game.GameContext) {
 func (ps *ParticleSystem) GetType() string {
 	return "particles"
 }
+
+func (ps *ParticleSystem) GetLayer() game.Layer {
+	return game.LayerEffects
+}
+
+func (ps *ParticleSystem) GetPhase() game.Phase {
+	return game.PhaseSimulate
+}
@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"math"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/omar0ali/spaceinvaders-game-cli/base"
+	"github.com/omar0ali/spaceinvaders-game-cli/game"
+)
+
+const (
+	lowHealth    = 0.25 // the vignette shows under this fraction of the health
+	killFreeze   = 0.05 // seconds
+	bossFreeze   = 0.25
+	shakeOnHit   = 1.0 // cells, scaled by screen_shake
+	shakeOnBoss  = 2.0
+	shakeOnDeath = 3.0
+)
+
+// PostFX is the impact feedback: hit flashes, screen shake, the low health vignette and
+// freeze-frames on kills. Each can be turned off in [effects].
+type PostFX struct {
+	cfg game.GameConfig
+}
+
+func NewPostFX(cfg game.GameConfig, gc *game.GameContext) *PostFX {
+	p := &PostFX{cfg: cfg}
+
+	game.Subscribe(gc, func(e game.PlayerDamaged) {
+		p.Shake(shakeOnHit, 200*time.Millisecond)
+	})
+	game.Subscribe(gc, func(e game.EnemyKilled) {
+		freeze := killFreeze
+		if e.Boss {
+			freeze = bossFreeze
+			p.Shake(shakeOnDeath, 600*time.Millisecond)
+		}
+		if cfg.Effects.FreezeFrames {
+			gc.Freeze(freeze)
+		}
+	})
+	base.SetVignette(0)
+	return p
+}
+
+// HitFlash flashes the sprite of the object that was hit.
+func (p *PostFX) HitFlash(f Flashable) {
+	if p.cfg.Effects.HitFlash {
+		f.Flash()
+	}
+}
+
+// Shake offsets the playfield, intensity in cells is scaled by screen_shake.
+func (p *PostFX) Shake(intensity float64, duration time.Duration) {
+	if p.cfg.Effects.ScreenShake > 0 {
+		base.Shake(intensity*p.cfg.Effects.ScreenShake, duration)
+	}
+}
+
+func (p *PostFX) Update(gc *game.GameContext, delta float64) {
+	strength := 0.0
+	if s, ok := gc.FindEntity("spaceship").(*SpaceShip); ok && p.cfg.Effects.Vignette &&
+		gc.Scenes.Has(game.ScenePlaying) && s.MaxHealth > 0 && s.Health > 0 {
+		if left := float64(s.Health) / float64(s.MaxHealth); left < lowHealth {
+			// pulses faster and stronger the lower the health
+			pulse := (math.Sin(float64(time.Now().UnixMilli())/1000*(4+8*(1-left/lowHealth))) + 1) / 2
+			strength = 0.4 + 0.6*pulse*(1-left/lowHealth)
+		}
+	}
+	base.SetVignette(strength)
+}
+
+func (p *PostFX) Draw(gc *game.GameContext) {}
+
+func (p *PostFX) InputEvents(event tcell.Event, gc *game.GameContext) {}
+
+func (p *PostFX) GetType() string {
+	return "postfx"
+}
+
+func (p *PostFX) GetLayer() game.Layer {
+	return game.LayerEffects
+}
+
+func (p *PostFX) GetPhase() game.Phase {
+	return game.PhaseRender
+}
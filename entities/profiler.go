@@ -0,0 +1,224 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/omar0ali/spaceinvaders-game-cli/base"
+	"github.com/omar0ali/spaceinvaders-game-cli/entities/particles"
+	"github.com/omar0ali/spaceinvaders-game-cli/entities/ui"
+	"github.com/omar0ali/spaceinvaders-game-cli/game"
+)
+
+const (
+	profilerWidth = 50
+	graphWidth    = profilerWidth - 4
+	smoothing     = 0.1 // weight of the last frame in the averaged timings
+)
+
+var graphBars = []rune("▁▂▃▄▅▆▇█")
+
+type entityTiming struct {
+	update, draw float64 // averaged, in ms
+}
+
+// Profiler is a performance overlay toggled with F3 (dev.profiler in the config), F4
+// starts and stops a runtime trace.
+type Profiler struct {
+	Visible   bool
+	Tracing   bool
+	traceFile string
+	timings   map[string]*entityTiming
+}
+
+func NewProfiler(cfg game.GameConfig) *Profiler {
+	return &Profiler{
+		traceFile: cfg.Dev.TraceFile,
+		timings:   map[string]*entityTiming{},
+	}
+}
+
+func (p *Profiler) InputEvents(event tcell.Event, gc *game.GameContext) {
+	ev, ok := event.(*tcell.EventKey)
+	if !ok {
+		return
+	}
+	switch ev.Key() {
+	case tcell.KeyF3:
+		p.Visible = !p.Visible
+		gc.Frame.Profiling = p.Visible
+	case tcell.KeyF4:
+		tracing, err := game.ToggleTrace(p.traceFile)
+		if err != nil {
+			game.Logger(game.SubsystemGame).Error("trace failed", "err", err)
+			SetStatus("Trace failed: "+err.Error(), gc)
+			return
+		}
+		p.Tracing = tracing
+		if tracing {
+			SetStatus("Tracing to "+p.traceFile, gc)
+		} else {
+			SetStatus("Trace saved to "+p.traceFile, gc)
+		}
+	}
+}
+
+func (p *Profiler) Update(gc *game.GameContext, delta float64) {
+	if !p.Visible {
+		return
+	}
+	// the spans are named <type>.update and <type>.draw
+	frame := map[string]*entityTiming{}
+	for _, span := range gc.Frame.LastSpans {
+		name, phase, ok := strings.Cut(span.Name, ".")
+		if !ok {
+			continue
+		}
+		t, ok := frame[name]
+		if !ok {
+			t = &entityTiming{}
+			frame[name] = t
+		}
+		ms := float64(span.Duration) / float64(time.Millisecond)
+		if phase == "update" {
+			t.update += ms
+		} else {
+			t.draw += ms
+		}
+	}
+	for name, t := range frame {
+		avg, ok := p.timings[name]
+		if !ok {
+			p.timings[name] = t
+			continue
+		}
+		avg.update += (t.update - avg.update) * smoothing
+		avg.draw += (t.draw - avg.draw) * smoothing
+	}
+}
+
+func (p *Profiler) Draw(gc *game.GameContext) {
+	if !p.Visible {
+		return
+	}
+	w, _ := base.GetSize()
+	lines := p.lines(gc)
+	whiteColor := base.StyleIt(tcell.ColorWhite)
+	greenColor := base.StyleIt(tcell.ColorGreenYellow)
+
+	ui.DrawBoxOverlap(base.Point{X: w - profilerWidth, Y: 0}, profilerWidth, len(lines)+3, func(x, y int) {
+		for j, line := range lines {
+			style := whiteColor
+			if j == 1 {
+				style = greenColor
+			}
+			for i, r := range []rune(line) {
+				base.SetContentWithStyle(x+i+2, y+j+1, r, style)
+			}
+		}
+	}, greenColor)
+}
+
+// lines returns the frame times, the graph, the counts and the timings by entity type.
+func (p *Profiler) lines(gc *game.GameContext) []string {
+	history := gc.Frame.History
+	history = history[max(len(history)-graphWidth, 0):]
+
+	var last, peak, total time.Duration
+	for _, d := range history {
+		total += d
+		peak = max(peak, d)
+	}
+	if len(history) > 0 {
+		last = history[len(history)-1]
+		total /= time.Duration(len(history))
+	}
+
+	// the graph is scaled to the slowest frame shown
+	graph := make([]rune, len(history))
+	for i, d := range history {
+		index := 0
+		if peak > 0 {
+			index = int(int64(d) * int64(len(graphBars)-1) / int64(peak))
+		}
+		graph[i] = graphBars[index]
+	}
+
+	playing, limit := gc.Sounds.Voices()
+	render := base.GetRenderStats()
+	lines := []string{
+		fmt.Sprintf("Frame %s avg %s max %s", ms(last), ms(total), ms(peak)),
+		string(graph),
+		fmt.Sprintf("Entities %d Beams %d Particles %d", len(gc.GetEntities()), beamCount(gc), particleCount(gc)),
+		fmt.Sprintf("Voices %d/%d", playing, limit),
+		fmt.Sprintf("Render %d cells %.1fKB/s %dfps (%d skipped)",
+			render.CellsChanged, render.BytesPerSecond/1024, render.FPS, render.FramesSkipped),
+		"",
+		fmt.Sprintf("%-14s %10s %10s", "Entity", "Update", "Draw"),
+	}
+
+	names := make([]string, 0, len(p.timings))
+	for name := range p.timings {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := p.timings[names[i]], p.timings[names[j]]
+		return a.update+a.draw > b.update+b.draw
+	})
+	for _, name := range names {
+		t := p.timings[name]
+		lines = append(lines, fmt.Sprintf("%-14s %8.3fms %8.3fms", name, t.update, t.draw))
+	}
+
+	trace := "off"
+	if p.Tracing {
+		trace = "recording to " + p.traceFile
+	}
+	return append(lines, "", "F4 trace: "+trace)
+}
+
+func ms(d time.Duration) string {
+	return fmt.Sprintf("%.2fms", float64(d)/float64(time.Millisecond))
+}
+
+// beamCount counts the beams of the spaceship, the aliens and the boss.
+func beamCount(gc *game.GameContext) int {
+	count := 0
+	if spaceship, ok := gc.FindEntity("spaceship").(*SpaceShip); ok {
+		count += len(spaceship.GetBeams())
+	}
+	if a, ok := gc.FindEntity("alien").(*AlienProducer); ok {
+		for _, alien := range a.Aliens {
+			count += len(alien.GetBeams())
+		}
+	}
+	if b, ok := gc.FindEntity("boss").(*BossProducer); ok && b.BossAlien != nil {
+		count += len(b.BossAlien.GetBeams())
+	}
+	return count
+}
+
+func particleCount(gc *game.GameContext) int {
+	count := 0
+	if ps, ok := gc.FindEntity("particles").(*particles.ParticleSystem); ok {
+		for _, p := range ps.ParticleProducable {
+			count += p.GetTotalParticles()
+		}
+	}
+	return count
+}
+
+func (p *Profiler) GetType() string {
+	return "profiler"
+}
+
+func (p *Profiler) GetLayer() game.Layer {
+	return game.LayerMenu
+}
+
+func (p *Profiler) GetPhase() game.Phase {
+	return game.PhaseRender
+}
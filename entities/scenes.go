@@ -0,0 +1,147 @@
+package entities
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/omar0ali/spaceinvaders-game-cli/entities/ui"
+	"github.com/omar0ali/spaceinvaders-game-cli/game"
+)
+
+// scene is a game.Scene made of optional hooks and the entities it owns.
+type scene struct {
+	name     game.SceneName
+	halts    bool
+	entities []game.Entity
+	enter    func(gc *game.GameContext)
+	exit     func(gc *game.GameContext)
+	input    func(event tcell.Event, gc *game.GameContext) bool
+}
+
+func (s *scene) GetName() game.SceneName {
+	return s.name
+}
+
+func (s *scene) Entities() []game.Entity {
+	return s.entities
+}
+
+func (s *scene) Enter(gc *game.GameContext) {
+	if s.enter != nil {
+		s.enter(gc)
+	}
+}
+
+func (s *scene) Exit(gc *game.GameContext) {
+	if s.exit != nil {
+		s.exit(gc)
+	}
+}
+
+func (s *scene) InputEvents(event tcell.Event, gc *game.GameContext) bool {
+	return s.input != nil && s.input(event, gc)
+}
+
+func (s *scene) Halts() bool {
+	return s.halts
+}
+
+// clearLayout hides the menu of the scene being exited.
+func clearLayout(gc *game.GameContext) {
+	if layout, ok := gc.FindEntity("layout").(*ui.UISystem); ok {
+		layout.SetLayout(nil)
+	}
+}
+
+func isPauseKey(event tcell.Event) bool {
+	ev, ok := event.(*tcell.EventKey)
+	return ok && (ev.Rune() == 'p' || ev.Rune() == 'P' || ev.Key() == tcell.KeyESC)
+}
+
+// MainMenu → ShipSelect → Playing ⇄ Paused/LevelUp → GameOver
+
+func (u *UI) mainMenuScene() game.Scene {
+	return &scene{
+		name:  game.SceneMainMenu,
+		halts: true,
+		enter: u.mainMenu,
+	}
+}
+
+func (u *UI) shipSelectScene() game.Scene {
+	return &scene{
+		name:  game.SceneShipSelect,
+		halts: true,
+		enter: u.shipSelection,
+		exit:  clearLayout,
+	}
+}
+
+func (u *UI) playingScene() game.Scene {
+	return &scene{
+		name: game.ScenePlaying,
+		input: func(event tcell.Event, gc *game.GameContext) bool {
+			if !isPauseKey(event) {
+				return false
+			}
+			gc.Sounds.PlaySound("ui_select")
+			gc.Scenes.Push(gc, u.pausedScene())
+			return true
+		},
+	}
+}
+
+func (u *UI) pausedScene() game.Scene {
+	return &scene{
+		name:  game.ScenePaused,
+		halts: true,
+		enter: u.pauseMenu,
+		exit:  clearLayout,
+		input: func(event tcell.Event, gc *game.GameContext) bool {
+			if !isPauseKey(event) {
+				return false
+			}
+			gc.Sounds.PlaySound("ui_select")
+			u.resume(gc)
+			return true
+		},
+	}
+}
+
+// gameOverScene owns the run report, which takes the scroll and export keys.
+func (u *UI) gameOverScene() game.Scene {
+	return &scene{
+		name:     game.SceneGameOver,
+		halts:    true,
+		entities: []game.Entity{&GameOverScreen{ui: u}},
+	}
+}
+
+// levelUpScene is pushed by LevelUpMenu and shows the talent tree until a talent is
+// learned or the level up is skipped.
+func (s *SpaceShip) levelUpScene() game.Scene {
+	return &scene{
+		name:  game.SceneLevelUp,
+		halts: true,
+		enter: s.talentTreeMenu,
+		exit:  clearLayout,
+	}
+}
+
+// consoleScene takes all the input while the console is open.
+func (c *Console) consoleScene() game.Scene {
+	return &scene{
+		name:  game.SceneConsole,
+		halts: true,
+		enter: func(gc *game.GameContext) {
+			c.Open = true
+			c.input = nil
+		},
+		exit: func(gc *game.GameContext) {
+			c.Open = false
+			c.input = nil
+		},
+		input: func(event tcell.Event, gc *game.GameContext) bool {
+			c.consoleInput(event, gc)
+			return true
+		},
+	}
+}
@@ -0,0 +1,103 @@
+package entities
+
+import (
+	"fmt"
+
+	"github.com/omar0ali/spaceinvaders-game-cli/entities/ui"
+	"github.com/omar0ali/spaceinvaders-game-cli/game"
+)
+
+// volumeSteps are the volumes listed for every channel in the settings.
+var volumeSteps = []float64{0, 0.2, 0.4, 0.6, 0.8, 1}
+
+type audioChannel struct {
+	name    string
+	bus     game.Bus // master when empty
+	preview string   // sound event of the bus, played when its volume changes
+}
+
+var audioChannels = []audioChannel{
+	{name: "Master", preview: "ui_select"},
+	{name: "Sound Effects", bus: game.BusSFX, preview: "alien_explosion"},
+	{name: "Interface", bus: game.BusUI, preview: "ui_hover"},
+	{name: "Music", bus: game.BusMusic},
+	{name: "Alerts", bus: game.BusAlerts, preview: "level_up"},
+}
+
+func channelDesc(c audioChannel, settings game.AudioSettings) []string {
+	volume, muted := settings.Master, settings.Muted
+	if c.bus != "" {
+		volume, muted = settings.Volume(c.bus), settings.IsMuted(c.bus)
+	}
+	status := "On"
+	if muted {
+		status = "Muted"
+	}
+	return []string{
+		fmt.Sprintf("- [%s]", c.name),
+		fmt.Sprintf("* Volume: %d%%", int(volume*100+0.5)),
+		fmt.Sprintf("* Status: %s", status),
+		"Click a volume to hear it, [M] mutes everything.",
+	}
+}
+
+// SettingsMenu changes the volume of the master and of every channel, the changes are
+// heard right away. back returns to the menu it was opened from.
+func (u *UI) SettingsMenu(gc *game.GameContext, layout *ui.UISystem, back func()) {
+	sounds := gc.Sounds
+	layoutSettings := ui.InitCodexMenu(20, 5)
+	layoutSettings.SelectedDesc = []string{"Settings - Audio"}
+
+	var channelItems func(c audioChannel) []*ui.Box
+	channelItems = func(c audioChannel) []*ui.Box {
+		preview := func() {
+			if c.preview != "" {
+				sounds.PlaySound(c.preview)
+			}
+			layoutSettings.SetList(channelItems(c))
+			layoutSettings.SelectedDesc = channelDesc(c, sounds.Settings)
+		}
+
+		var boxes []*ui.Box
+		for _, volume := range volumeSteps {
+			boxes = append(boxes, ui.NewUIBox(
+				[]string{fmt.Sprintf("%d%%", int(volume*100))},
+				channelDesc(c, sounds.Settings),
+				func() {
+					sounds.SetVolume(c.bus, volume)
+					preview()
+				}))
+		}
+
+		muted := sounds.Settings.Muted
+		if c.bus != "" {
+			muted = sounds.Settings.IsMuted(c.bus)
+		}
+		toggle := "Mute"
+		if muted {
+			toggle = "Unmute"
+		}
+		return append(boxes, ui.NewUIBox([]string{toggle}, channelDesc(c, sounds.Settings), func() {
+			sounds.SetMuted(c.bus, !muted)
+			preview()
+		}))
+	}
+
+	var menu []*ui.Box
+	for _, c := range audioChannels {
+		menu = append(menu, ui.NewUIBox([]string{c.name}, channelDesc(c, sounds.Settings), func() {
+			layoutSettings.SetList(channelItems(c))
+			layoutSettings.SelectedDesc = channelDesc(c, sounds.Settings)
+		}))
+	}
+	menu = append(menu, ui.NewUIBox(
+		[]string{
+			"< Back",
+		},
+		[]string{
+			"Back to the menu.",
+		}, back))
+
+	layoutSettings.SetMenuItems(menu)
+	layout.SetLayout(layoutSettings)
+}
@@ -2,9 +2,9 @@
 package entities
 
 import (
+	"cmp"
 	"fmt"
 	"math"
-	"math/rand"
 	"sort"
 	"time"
 
@@ -22,6 +22,7 @@ type SpaceshipReport struct {
 		Power int
 	}
 	RegisteredHits map[string]int
+	Stats          RunStats
 }
 
 func (s *SpaceshipReport) Report(name string, power int) {
@@ -31,6 +32,7 @@ func (s *SpaceshipReport) Report(name string, power int) {
 
 type Score struct {
 	Score          int
+	TotalScore     int // score of the whole run, Score resets on every level
 	Level          int
 	Kills          int
 	PreviousLevel  int
@@ -54,12 +56,21 @@ type SpaceShip struct {
 	base.Gun
 	base.ObjectBase
 	Score
-	HealthKit         HealthKit
+	HealthKit    HealthKit
+	Weapons      []*base.Weapon // switched with number keys, the primary gun is always [1]
+	ActiveWeapon int            // 0 is the primary gun
+	Bomb         *base.Weapon   // secondary weapon
+	Effects
+	Talents           Talents
 	cfg               game.GameConfig
-	OnLevelUp         []func(newLevel int)
 	SelectedSpaceship *design.SpaceshipDesign
+	animator          base.Animator
 	LoadedDesigns     *design.LoadedDesigns
 	mouseDown         bool
+	CreditsEarned     int
+	gameOver          bool
+	God               bool // ignores all damage, set from the console
+	damagedThisLevel  bool // for the no damage achievement
 	SpaceshipReport
 }
 
@@ -89,7 +100,7 @@ func (s *SpaceShip) GetRegisteredHits() []string {
 }
 
 func (s *SpaceShip) RegisterHit(entity string) {
-	game.Log(game.Debug, "registered hit")
+	game.Logger(game.SubsystemCollision).Debug("registered hit", "entity", entity)
 	s.RegisteredHits[entity] = s.RegisteredHits[entity] + 1
 }
 
@@ -111,10 +122,6 @@ func (s *SpaceShip) IncreaseHealth(i int) bool {
 	return false
 }
 
-func (s *SpaceShip) AddOnLevelUp(fn func(newLevel int)) {
-	s.OnLevelUp = append(s.OnLevelUp, fn)
-}
-
 // player initialized in the bottom center of the secreen by default
 
 func NewSpaceShip(cfg game.GameConfig, gc *game.GameContext, designs *design.LoadedDesigns) *SpaceShip {
@@ -124,7 +131,7 @@ func NewSpaceShip(cfg game.GameConfig, gc *game.GameContext, designs *design.Loa
 		Y: float64(h - 3),
 	}
 
-	return &SpaceShip{
+	s := &SpaceShip{
 		ObjectBase: base.ObjectBase{
 			ObjectEntity: base.ObjectEntity{
 				Position: origin,
@@ -141,8 +148,48 @@ func NewSpaceShip(cfg game.GameConfig, gc *game.GameContext, designs *design.Loa
 		},
 		SpaceshipReport: SpaceshipReport{
 			RegisteredHits: map[string]int{},
+			Stats:          NewRunStats(),
 		},
 	}
+	s.subscribe(gc)
+	s.subscribeStats(gc)
+	return s
+}
+
+// subscribe registers the scoring, damage report and achievements listeners.
+func (s *SpaceShip) subscribe(gc *game.GameContext) {
+	game.Subscribe(gc, func(e game.Resized) {
+		// keep the spaceship inside the playfield
+		rescale(&s.Position, e)
+		s.Position.X = min(max(s.Position.X, 0), float64(max(e.Width-s.Width, 0)))
+		s.Position.Y = min(max(s.Position.Y, 0), float64(max(e.Height-s.Height, 0)))
+	})
+	game.Subscribe(gc, func(e game.EnemyKilled) {
+		s.ScoreKill(e.Health)
+		s.Achieve(gc, design.EventKill, e.Name, 1)
+		if e.Boss {
+			s.Achieve(gc, design.EventBossDown, e.Name, 1)
+		}
+	})
+	game.Subscribe(gc, func(e game.PickupCollected) {
+		s.ScoreHit()
+	})
+	game.Subscribe(gc, func(e game.PlayerDamaged) {
+		if e.Hit != "" {
+			s.RegisterHit(e.Hit)
+		}
+		s.Report(e.Source, e.Power)
+	})
+	game.Subscribe(gc, func(e game.LevelUp) {
+		s.Achieve(gc, design.EventLevelReached, "", e.Level)
+		if !s.damagedThisLevel {
+			s.Achieve(gc, design.EventNoDamageWave, "", 1)
+		}
+		s.damagedThisLevel = false
+	})
+	game.Subscribe(gc, func(e game.GameOver) {
+		s.rewardRun(gc)
+	})
 }
 
 func (s *SpaceShip) SpaceshipSelection(id int) string {
@@ -158,15 +205,162 @@ func (s *SpaceShip) SpaceshipSelection(id int) string {
 	s.MaxHealth = s.LoadedDesigns.ListOfSpaceships[id].EntityHealth
 	s.Width = len(s.LoadedDesigns.ListOfSpaceships[id].Shape[0])
 	s.Height = len(s.LoadedDesigns.ListOfSpaceships[id].Shape)
+	s.SetDefense(s.SelectedSpaceship.Defense)
+	s.Talents = NewTalents(s.LoadedDesigns, s.SelectedSpaceship.Name)
+	s.Stats.Ship = s.SelectedSpaceship.Name
+	s.SetDamageType(s.SelectedSpaceship.DamageType)
+
+	s.Weapons = nil
+	s.ActiveWeapon = 0
+	for _, weaponDesign := range s.LoadedDesigns.ListOfWeapons {
+		if weaponDesign.Kind == design.Bomb {
+			s.Bomb = base.NewWeapon(weaponDesign)
+			continue
+		}
+		s.Weapons = append(s.Weapons, base.NewWeapon(weaponDesign))
+	}
 	return s.SelectedSpaceship.Name
 }
 
+// guns returns every gun the spaceship carries, starting with the primary gun.
+func (s *SpaceShip) guns() []*base.Gun {
+	guns := []*base.Gun{&s.Gun}
+	for _, w := range s.Weapons {
+		guns = append(guns, &w.Gun)
+	}
+	return guns
+}
+
+// GunFor finds the gun of the weapon by name, falls back to the primary gun.
+func (s *SpaceShip) GunFor(name string) *base.Gun {
+	for _, w := range s.Weapons {
+		if w.Name == name {
+			return &w.Gun
+		}
+	}
+	if s.Bomb != nil && s.Bomb.Name == name {
+		return &s.Bomb.Gun
+	}
+	return &s.Gun
+}
+
+func (s *SpaceShip) GetActiveGun() *base.Gun {
+	return s.guns()[s.ActiveWeapon]
+}
+
+func (s *SpaceShip) GetActiveWeaponName() string {
+	if s.ActiveWeapon == 0 {
+		return "Blaster"
+	}
+	return s.Weapons[s.ActiveWeapon-1].Name
+}
+
+func (s *SpaceShip) SwitchWeapon(i int, gc *game.GameContext) {
+	if i < 0 || i > len(s.Weapons) || i == s.ActiveWeapon {
+		return
+	}
+	s.ActiveWeapon = i
+	gc.Sounds.PlaySound("weapon_switch")
+	SetStatus(fmt.Sprintf("[%d] Weapon: %s", i+1, s.GetActiveWeaponName()), gc)
+}
+
+// GetBeams returns the beams of all the weapons, a beam keeps flying after switching weapons.
+func (s *SpaceShip) GetBeams() []*base.Beam {
+	var beams []*base.Beam
+	for _, g := range s.guns() {
+		beams = append(beams, g.GetBeams()...)
+	}
+	return beams
+}
+
+func (s *SpaceShip) RemoveBeam(beam *base.Beam) {
+	for _, g := range s.guns() {
+		g.RemoveBeam(beam)
+	}
+}
+
+func (s *SpaceShip) Spend(beam *base.Beam, target any) {
+	for _, g := range s.guns() {
+		for _, b := range g.GetBeams() {
+			if b == beam {
+				g.Spend(beam, target)
+				return
+			}
+		}
+	}
+}
+
+// DropBomb clears all the enemy beams on the screen.
+func (s *SpaceShip) DropBomb(gc *game.GameContext) {
+	if s.Bomb == nil {
+		return
+	}
+	if s.Bomb.IsReloading() {
+		SetStatus("[B] Bomb: Rearming", gc)
+		return
+	}
+	if !s.Bomb.Trigger(gc.Sounds) {
+		return
+	}
+
+	if a, ok := gc.FindEntity("alien").(*AlienProducer); ok {
+		for _, alien := range a.Aliens {
+			alien.ClearBeams()
+		}
+	}
+	if b, ok := gc.FindEntity("boss").(*BossProducer); ok {
+		if b.BossAlien != nil {
+			b.BossAlien.ClearBeams()
+		}
+	}
+	if p, ok := gc.FindEntity("particles").(*particles.ParticleSystem); ok {
+		p.Emit(cmp.Or(s.Bomb.ImpactEffect, "bomb_blast"), s.Position.X, s.Position.Y, s.Width, s.Height, s.Bomb.Color)
+	}
+	SetStatus(fmt.Sprintf("[B] Bomb: Enemy fire cleared (%d/%d)", s.Bomb.GetLoaded(), s.Bomb.GetCapacity()), gc)
+}
+
+// closestEnemyX used by homing missiles to find the closest enemy ship above the beam.
+func (s *SpaceShip) closestEnemyX(gc *game.GameContext, from base.Point) (int, bool) {
+	var enemies []*base.Enemy
+	if a, ok := gc.FindEntity("alien").(*AlienProducer); ok {
+		enemies = append(enemies, a.Aliens...)
+	}
+	if b, ok := gc.FindEntity("boss").(*BossProducer); ok && b.BossAlien != nil {
+		enemies = append(enemies, b.BossAlien)
+	}
+
+	found := false
+	closest, closestDistance := 0, math.MaxFloat64
+	for _, e := range enemies {
+		if int(e.Position.Y) > from.Y {
+			continue
+		}
+		x := int(e.Position.X) + e.Width/2
+		distance := math.Hypot(float64(x-from.X), e.Position.Y-float64(from.Y))
+		if distance < closestDistance {
+			closest, closestDistance, found = x, distance, true
+		}
+	}
+	return closest, found
+}
+
 func (s *SpaceShip) Update(gc *game.GameContext, delta float64) {
-	defer s.Gun.Update(gc, delta)
+	defer func() {
+		for _, g := range s.guns() {
+			g.Steer(func(from base.Point) (int, bool) {
+				return s.closestEnemyX(gc, from)
+			})
+			g.Update(gc, delta)
+		}
+	}()
 	if s.Health <= 0 && s.SelectedSpaceship != nil {
-		gc.Sounds.PlaySound("8-bit-game-over.mp3", -1)
-		if ui, ok := gc.FindEntity("ui").(*UI); ok {
-			ui.GameOverScreen = true
+		gc.Sounds.PlaySound("game_over")
+		if !s.gameOver {
+			s.gameOver = true
+			game.Publish(gc, game.GameOver{Score: s.TotalScore, Kills: s.Kills, Level: s.Level})
+			if u, ok := gc.FindEntity("ui").(*UI); ok {
+				gc.Scenes.Replace(gc, u.gameOverScene())
+			}
 		}
 	}
 	if s.Score.Score >= s.NextLevelScore {
@@ -174,9 +368,18 @@ func (s *SpaceShip) Update(gc *game.GameContext, delta float64) {
 		s.NextLevelScore += s.cfg.SpaceShipConfig.NextLevelScore
 	}
 
-	if s.mouseDown {
-		s.shootBeam(gc)
+	w, h := base.GetSize()
+	center := emitterOf(s)
+	gc.Sounds.SetListener(center.X, center.Y, w, h)
+
+	s.shootBeam(gc, delta)
+	s.RegenerateShield(delta)
+	if s.SelectedSpaceship != nil {
+		s.Stats.Update(delta, s.TotalScore, s.Health)
+		d := &s.SelectedSpaceship.Design
+		s.animator.Animate(d, d.AnimationFor(s.Health, s.MaxHealth), delta)
 	}
+	s.UpdateEffects(delta, gc)
 
 	s.LevelUp(gc)
 
@@ -188,19 +391,14 @@ func (s *SpaceShip) Draw(gc *game.GameContext) {
 		return
 	}
 
-	color := base.StyleIt(s.SelectedSpaceship.GetColor())
-
-	defer s.Gun.Draw(gc, s.SelectedSpaceship.GetColor())
-
-	for rowIndex, line := range s.SelectedSpaceship.Shape {
-		for colIndex, char := range line {
-			if char != ' ' {
-				x := int(s.Position.GetX()) + colIndex
-				y := int(s.Position.GetY()) + rowIndex
-				base.SetContentWithStyle(x, y, char, color)
-			}
+	defer func() {
+		s.Gun.Draw(gc, s.SelectedSpaceship.GetColor())
+		for _, w := range s.Weapons {
+			w.Draw(gc, w.GetColor())
 		}
-	}
+	}()
+
+	base.DrawSprite(int(s.Position.GetX()), int(s.Position.GetY()), s.Flashed(s.animator.Sprite(&s.SelectedSpaceship.Design)))
 
 	// display health bar at the bottom of the spaceship
 	barSize := 7
@@ -212,7 +410,12 @@ func (s *SpaceShip) Draw(gc *game.GameContext) {
 		),
 		base.WithBarSize(barSize),
 		base.WithStyle(base.StyleIt(tcell.ColorGreenYellow)),
-		base.WithGun(&s.Gun),
+		base.WithGun(s.GetActiveGun()),
+	)
+	s.DisplayShield(
+		int(s.Position.GetX())+(s.Width/2)-(barSize/2)-1,
+		int(s.Position.GetY())+(s.Height)+1,
+		barSize,
 	)
 
 	// -1 because there are the brackets []. So the barSize+[] which is + 2.
@@ -230,10 +433,10 @@ func (s *SpaceShip) InputEvents(event tcell.Event, gc *game.GameContext) {
 
 	switch ev := event.(type) {
 	case *tcell.EventMouse:
-		if gc.Halt {
+		if gc.Scenes.Halted() {
 			return
 		}
-		x, y := ev.Position()
+		x, y := base.MousePosition(ev)
 		moveMouse(x, y)
 
 		// buttons() contains (0000 0001, 0000 0100, 0000 0101)
@@ -246,8 +449,8 @@ func (s *SpaceShip) InputEvents(event tcell.Event, gc *game.GameContext) {
 		}
 
 		if ev.Buttons() == tcell.Button2 {
-			if s.GetLoaded() != s.GetCapacity() {
-				s.ReloadGun(gc.Sounds)
+			if gun := s.GetActiveGun(); gun.GetLoaded() != gun.GetCapacity() {
+				gun.ReloadGun(gc.Sounds)
 			}
 		}
 
@@ -261,6 +464,7 @@ func (s *SpaceShip) InputEvents(event tcell.Event, gc *game.GameContext) {
 					if s.IncreaseHealth(int(p.Level)) {
 						SetStatus(fmt.Sprintf("[E] Health: Consumed +%d", int(p.Level)), gc)
 						s.HealthKit.HealthKitsOwned--
+						s.Achieve(gc, design.EventKitUsed, "", 1)
 						return
 					}
 				}
@@ -270,10 +474,16 @@ func (s *SpaceShip) InputEvents(event tcell.Event, gc *game.GameContext) {
 			}
 		}
 		if ev.Rune() == 'R' || ev.Rune() == 'r' {
-			if s.GetLoaded() != s.GetCapacity() {
-				s.ReloadGun(gc.Sounds)
+			if gun := s.GetActiveGun(); gun.GetLoaded() != gun.GetCapacity() {
+				gun.ReloadGun(gc.Sounds)
 			}
 		}
+		if ev.Rune() == 'B' || ev.Rune() == 'b' {
+			s.DropBomb(gc)
+		}
+		if ev.Rune() >= '1' && ev.Rune() <= '9' {
+			s.SwitchWeapon(int(ev.Rune()-'1'), gc)
+		}
 
 	}
 }
@@ -289,8 +499,8 @@ func (s *SpaceShip) UISpaceshipData(gc *game.GameContext) {
 	_, h := base.GetSize()
 	ui.DrawBoxOverlap(
 		base.Point{
-			X: 0, Y: h - 8,
-		}, 23, 6, func(x int, y int) {
+			X: 0, Y: h - 10,
+		}, 23, 8, func(x int, y int) {
 			// display health bar of the spaceship at bottom left of the screen
 			base.DisplayBar(s, base.WithPosition(x+2, y+1),
 				base.WithBarSize(17),
@@ -299,22 +509,36 @@ func (s *SpaceShip) UISpaceshipData(gc *game.GameContext) {
 			)
 
 			for i, r := range fmt.Sprintf("Level:  %d", s.Level) {
-				base.SetContentWithStyle(x+i+2, h-6, r, whiteColor)
+				base.SetContentWithStyle(x+i+2, y+2, r, whiteColor)
+			}
+
+			for i, r := range fmt.Sprintf("[%d] %s", s.ActiveWeapon+1, s.GetActiveWeaponName()) {
+				base.SetContentWithStyle(x+i+2, y+3, r, whiteColor)
 			}
 
-			str := fmt.Sprintf("CAP:    %d/%d", s.GetLoaded(), s.GetCapacity())
+			gun := s.GetActiveGun()
+			str := fmt.Sprintf("CAP:    %d/%d", gun.GetLoaded(), gun.GetCapacity())
+
+			if s.ActiveWeapon > 0 && s.Weapons[s.ActiveWeapon-1].Kind == design.Charge {
+				str += fmt.Sprintf(" %3.f%%", s.Weapons[s.ActiveWeapon-1].ChargeRatio()*100)
+			}
 
-			if s.IsReloading() {
+			if gun.IsReloading() {
 				reloadAnimation := []rune{'·', '•', '●', '○', '●', '•', '·'}
 				frame := int(time.Now().UnixNano()/100_000_000) % len(reloadAnimation)
 				str += " " + string(reloadAnimation[frame])
 			}
 
 			for i, r := range string(str) {
-				base.SetContentWithStyle(x+i+2, h-5, r, whiteColor)
+				base.SetContentWithStyle(x+i+2, y+4, r, whiteColor)
 			}
 			for i, r := range fmt.Sprintf("HP Kit: %d/%d", s.HealthKit.HealthKitsOwned, s.HealthKit.HealthKitLimit) {
-				base.SetContentWithStyle(x+i+2, h-4, r, whiteColor)
+				base.SetContentWithStyle(x+i+2, y+5, r, whiteColor)
+			}
+			if s.Bomb != nil {
+				for i, r := range fmt.Sprintf("Bomb:   %d/%d", s.Bomb.GetLoaded(), s.Bomb.GetCapacity()) {
+					base.SetContentWithStyle(x+i+2, y+6, r, whiteColor)
+				}
 			}
 		}, greenColor)
 }
@@ -325,34 +549,35 @@ func (s *SpaceShip) MovementAndCollision(delta float64, gc *game.GameContext) {
 			// check alien shooting the spaceship
 			for _, alienBeam := range alien.GetBeams() {
 				if s.isHit(alienBeam.GetPosition(), gc) {
-					s.TakeDamage(alien.GetPower())
+					s.TakeDamage(alienBeam.Damage())
 					alien.RemoveBeam(alienBeam)
-					s.RegisterHit(fmt.Sprintf("%s POW: %d", alien.Name, alien.GunPower))
-					s.Report(alien.Name, alien.GetPower())
+					game.Publish(gc, game.PlayerDamaged{
+						Source: alien.Name,
+						Power:  alien.GetPower(),
+						Hit:    fmt.Sprintf("%s POW: %d", alien.Name, alien.GunPower),
+					})
 				}
 			}
 			if Crash(&s.ObjectBase, &alien.ObjectBase, gc) {
-				s.TakeDamage(1)
-				alien.TakeDamage(5)
-				s.RegisterHit(fmt.Sprintf("Crashed %s", alien.Name))
-				s.Report(alien.Name, alien.GetPower())
+				s.TakeDamage(base.CollisionDamage(1))
+				alien.TakeDamage(base.CollisionDamage(5))
+				game.Publish(gc, game.PlayerDamaged{
+					Source: alien.Name,
+					Power:  alien.GetPower(),
+					Hit:    fmt.Sprintf("Crashed %s", alien.Name),
+				})
 			}
 		}
 	}
 
-	// can collid with a meteroid
+	// can collid with debris
 	if ps, ok := gc.FindEntity("particles").(*particles.ParticleSystem); ok {
-		for _, p := range ps.ParticleProducable {
-			switch p.(type) {
-			case *particles.MeteroidProducer:
-				for _, m := range p.GetParticles() {
-					if Crash(&s.ObjectBase, &m.ObjectEntity, gc) {
-						s.TakeDamage(2)
-						p.RemoveParticle(m)
-						s.RegisterHit("Crashed Meteroid")
-						s.Report("Meteroid", 2)
-
-					}
+		for _, p := range ps.Colliding() {
+			for _, m := range p.GetParticles() {
+				if Crash(&s.ObjectBase, &m.ObjectEntity, gc) {
+					s.TakeDamage(base.CollisionDamage(2))
+					p.RemoveParticle(m)
+					game.Publish(gc, game.PlayerDamaged{Source: "Meteroid", Power: 2, Hit: "Crashed Meteroid"})
 				}
 			}
 		}
@@ -362,19 +587,22 @@ func (s *SpaceShip) MovementAndCollision(delta float64, gc *game.GameContext) {
 		if b.BossAlien != nil {
 			for _, bossBeam := range b.BossAlien.GetBeams() {
 				if s.isHit(bossBeam.GetPosition(), gc) {
-					s.TakeDamage(b.BossAlien.GetPower())
+					s.TakeDamage(bossBeam.Damage())
 					b.BossAlien.RemoveBeam(bossBeam)
-					s.RegisterHit(fmt.Sprintf("%s POW: %d", b.BossAlien.Name, b.BossAlien.GunPower))
-					s.Report(b.BossAlien.Name, b.BossAlien.GetPower())
+					game.Publish(gc, game.PlayerDamaged{
+						Source: b.BossAlien.Name,
+						Power:  b.BossAlien.GetPower(),
+						Hit:    fmt.Sprintf("%s POW: %d", b.BossAlien.Name, b.BossAlien.GunPower),
+					})
 				}
 			}
 
 			// can collid with a asteroid
 
 			if Crash(&s.ObjectBase, &b.BossAlien.ObjectBase, gc) {
-				s.TakeDamage(1)
-				b.BossAlien.TakeDamage(5)
-				s.Report(b.BossAlien.Name, 1)
+				s.TakeDamage(base.CollisionDamage(1))
+				b.BossAlien.TakeDamage(base.CollisionDamage(5))
+				game.Publish(gc, game.PlayerDamaged{Source: b.BossAlien.Name, Power: 1})
 			}
 
 		}
@@ -382,10 +610,13 @@ func (s *SpaceShip) MovementAndCollision(delta float64, gc *game.GameContext) {
 	if a, ok := gc.FindEntity("asteroid").(*AsteroidProducer); ok {
 		for _, asteroid := range a.Asteroids {
 			if Crash(&s.ObjectBase, &asteroid.ObjectBase, gc) {
-				s.TakeDamage(2)
-				asteroid.TakeDamage(4)
-				s.RegisterHit(fmt.Sprintf("Crashed Asteroid %s", asteroid.Name))
-				s.Report(asteroid.Name, 2)
+				s.TakeDamage(base.CollisionDamage(2))
+				asteroid.TakeDamage(base.CollisionDamage(4))
+				game.Publish(gc, game.PlayerDamaged{
+					Source: asteroid.Name,
+					Power:  2,
+					Hit:    fmt.Sprintf("Crashed Asteroid %s", asteroid.Name),
+				})
 			}
 		}
 	}
@@ -396,20 +627,18 @@ func (s *SpaceShip) isHit(pointBeam base.PointInterface, gc *game.GameContext) b
 		int(pointBeam.GetX()) <= int(s.Position.GetX())+s.Width &&
 		int(pointBeam.GetY()) >= int(s.Position.GetY()) &&
 		int(pointBeam.GetY()) <= int(s.Position.GetY())+s.Height {
+		if fx, ok := gc.FindEntity("postfx").(*PostFX); ok {
+			fx.HitFlash(s)
+		}
+
+		if s.IsShieldUp() {
+			ShieldHit(pointBeam.GetX(), pointBeam.GetY(), gc)
+			return true
+		}
 
 		if p, ok := gc.FindEntity("particles").(*particles.ParticleSystem); ok {
-			p.AddParticles(
-				particles.InitExplosion(3,
-					particles.WithDimensions(
-						pointBeam.GetX(),
-						pointBeam.GetY(),
-						0,
-						0,
-					),
-					particles.WithSymbols([]rune("0%*;.")),
-				),
-			)
-			gc.Sounds.PlaySound("8-bit-explosion.mp3", -1)
+			p.Emit("player_hit", pointBeam.GetX(), pointBeam.GetY(), 0, 0)
+			gc.Sounds.PlaySound("player_hit", game.Emitter{X: pointBeam.GetX(), Y: pointBeam.GetY()})
 		}
 
 		return true
@@ -418,20 +647,21 @@ func (s *SpaceShip) isHit(pointBeam base.PointInterface, gc *game.GameContext) b
 }
 
 func (s *SpaceShip) ApplyAbility(eff design.AbilityEffect, max int) bool {
+	gun := s.GunFor(eff.Weapon)
 	if eff.PowerIncrease != 0 {
-		return s.IncreaseGunPower(eff.PowerIncrease)
+		return gun.IncreaseGunPower(eff.PowerIncrease)
 	}
 	if eff.SpeedIncrease != 0 {
-		return s.IncreaseGunSpeed(eff.SpeedIncrease, max)
+		return gun.IncreaseGunSpeed(eff.SpeedIncrease, max)
 	}
 	if eff.CapacityIncrease != 0 {
-		return s.IncreaseGunCap(eff.CapacityIncrease, max)
+		return gun.IncreaseGunCap(eff.CapacityIncrease, max)
 	}
 	if eff.CooldownDecrease != 0 {
-		return s.DecreaseCooldown(eff.CooldownDecrease)
+		return gun.DecreaseCooldown(eff.CooldownDecrease)
 	}
 	if eff.ReloadCooldownDecrease != 0 {
-		return s.DecreaseGunReloadCooldown(eff.ReloadCooldownDecrease)
+		return gun.DecreaseGunReloadCooldown(eff.ReloadCooldownDecrease)
 	}
 	if eff.HealthCpacity != 0 {
 		return s.IncreaseHealthCapacity(eff.HealthCpacity)
@@ -440,107 +670,52 @@ func (s *SpaceShip) ApplyAbility(eff design.AbilityEffect, max int) bool {
 }
 
 func (s *SpaceShip) LevelUpMenu(gc *game.GameContext) {
-	gc.Sounds.PlaySound("8-bit-game-sfx-levelup-menu.mp3", -1)
-	if layout, ok := gc.FindEntity("layout").(*ui.UISystem); ok {
-		if u, ok := gc.FindEntity("ui").(*UI); ok {
-
-			SetStatus("Level Up", gc)
-			u.LevelUpScreen = true
-
-			var boxes []*ui.Box
+	gc.Sounds.PlaySound("level_up")
+	if !s.Talents.HasAvailable() {
+		SetStatus("All talents learned!", gc)
+		return
+	}
 
-			upgrade := func(up func() bool) {
-				if up() {
-					u.LevelUpScreen = false
-					layout.SetLayout(nil)
-				}
+	SetStatus("Level Up", gc)
+	if !gc.Scenes.Is(game.SceneLevelUp) {
+		gc.Scenes.Push(gc, s.levelUpScene())
+	}
+}
 
-				// on very level up, should clear the screen from enemies
-				if a, ok := gc.FindEntity("alien").(*AlienProducer); ok {
-					// clear screen from aliens when the player levels up
-					a.Aliens = nil
-				}
-				if a, ok := gc.FindEntity("asteroid").(*AsteroidProducer); ok {
-					a.Asteroids = nil
-				}
-				if b, ok := gc.FindEntity("boss").(*BossProducer); ok {
-					if b.BossAlien != nil {
-						w, _ := base.GetSize()
-						// reposition the boss ship a little back, to give player free area when choosing
-						// to level up state. To avoid hitting it.
-						b.BossAlien.Position.X = float64(w / 2)
-						b.BossAlien.Position.Y = -2.0
-					}
-				}
+// talentTreeMenu shows the talent tree, entered by the level up scene.
+func (s *SpaceShip) talentTreeMenu(gc *game.GameContext) {
+	if layout, ok := gc.FindEntity("layout").(*ui.UISystem); ok {
+		onLearn := func() {
+			gc.Scenes.Pop(gc, game.SceneLevelUp)
 
+			// on very level up, should clear the screen from enemies
+			if a, ok := gc.FindEntity("alien").(*AlienProducer); ok {
+				// clear screen from aliens when the player levels up
+				a.Aliens = nil
 			}
-
-			displayUpgrade := func(v int, max string) string {
-				if v > 0 {
-					return fmt.Sprintf("+ %1.f %s", math.Abs(float64(v)), max)
-				} else if v < 0 {
-					return fmt.Sprintf("- %1.f", math.Abs(float64(v)))
-				}
-				return ""
+			if a, ok := gc.FindEntity("asteroid").(*AsteroidProducer); ok {
+				a.Asteroids = nil
 			}
-
-			for _, design := range s.LoadedDesigns.ListOfAbilities {
-				var displayMax string
-				if design.Effect.MaxValue > 0 {
-					displayMax = fmt.Sprintf("(Max: %d)", design.Effect.MaxValue)
+			if b, ok := gc.FindEntity("boss").(*BossProducer); ok {
+				if b.BossAlien != nil {
+					w, _ := base.GetSize()
+					// reposition the boss ship a little back, to give player free area when choosing
+					// to level up state. To avoid hitting it.
+					b.BossAlien.Position.X = float64(w / 2)
+					b.BossAlien.Position.Y = -2.0
 				}
-
-				increaseSpeed := design.Effect.SpeedIncrease
-				increaseCap := design.Effect.CapacityIncrease
-				decreaseCD := design.Effect.CooldownDecrease
-				decreaseRDCD := design.Effect.ReloadCooldownDecrease
-				increasePower := design.Effect.PowerIncrease
-				increaseHealthCap := design.Effect.HealthCpacity
-
-				boxes = append(
-					boxes,
-					ui.NewUIBox(
-						design.Shape,
-						[]string{
-							"(*) " + design.Name,
-							"Details: " + design.Description,
-							fmt.Sprintf("Gun Power: (%d) %s", s.GetPower(), displayUpgrade(increasePower, displayMax)),
-							fmt.Sprintf("Gun Capacity: (%d) %s", s.GetCapacity(), displayUpgrade(increaseCap, displayMax)),
-							fmt.Sprintf("Gun Speed: (%d) %s", s.GetSpeed(), displayUpgrade(increaseSpeed, displayMax)),
-							fmt.Sprintf("Gun Cooldown: (%d) %s", s.GetCooldown(), displayUpgrade(decreaseCD, displayMax)),
-							fmt.Sprintf("Gun Reload Cooldown: (%d) %s", s.GetReloadCooldown(), displayUpgrade(decreaseRDCD, displayMax)),
-							fmt.Sprintf("Health Capacity: (%d) %s", s.MaxHealth, displayUpgrade(increaseHealthCap, displayMax)),
-						},
-
-						func() {
-							upgrade(func() bool {
-								result := s.ApplyAbility(design.Effect, design.Effect.MaxValue)
-								if result {
-									if design.Status != "" {
-										SetStatus(design.Status, gc)
-									}
-								} else {
-									SetStatus("Ability Maxed Out!", gc)
-								}
-								return result
-							})
-						},
-					),
-				)
 			}
-
-			// shuffle the list
-			rand.Shuffle(len(boxes), func(i, j int) {
-				boxes[i], boxes[j] = boxes[j], boxes[i]
-			})
-
-			// pick the first 3 boxes
-			pickedBoxes := boxes[:3]
-
-			layout.SetLayout(
-				ui.InitLayout(21, 10, pickedBoxes...),
-			)
 		}
+
+		layout.SetLayout(
+			ui.InitTreeLayout(22, 4,
+				[]string{
+					"(*) Level Up: Pick a talent.",
+					"Hover or use the arrow keys, click or [Enter] to learn.",
+				},
+				s.talentTreeNodes(gc, onLearn)...,
+			),
+		)
 	}
 }
 
@@ -549,9 +724,7 @@ func (s *SpaceShip) LevelUp(gc *game.GameContext) {
 		if s.cfg.SpaceShipConfig.MaxLevel <= s.Level {
 			return // skip when reaching max level, will not increase any elements of other objects
 		}
-		for _, fn := range s.OnLevelUp {
-			fn(s.Level)
-		}
+		game.Publish(gc, game.LevelUp{Level: s.Level})
 
 		// pop up level up
 		s.LevelUpMenu(gc)
@@ -561,27 +734,66 @@ func (s *SpaceShip) LevelUp(gc *game.GameContext) {
 	}
 }
 
+func (s *SpaceShip) scoreMultiplier() int {
+	if s.HasEffect(design.DoubleScore) {
+		return 2
+	}
+	return 1
+}
+
 func (s *SpaceShip) ScoreKill(health int) {
 	s.Kills += 1
-	s.Score.Score += health
+	s.addScore(health * s.scoreMultiplier())
 }
 
 func (s *SpaceShip) ScoreHit() {
-	s.Score.Score += s.GetPower()
+	s.addScore(s.GetPower() * s.scoreMultiplier())
+}
+
+func (s *SpaceShip) addScore(score int) {
+	s.Score.Score += score
+	s.TotalScore += score
+}
+
+// TakeDamage ignores all damage while the invulnerable effect is active.
+func (s *SpaceShip) TakeDamage(d base.Damage) bool {
+	if s.God || s.HasEffect(design.Invulnerable) {
+		return true
+	}
+	s.damagedThisLevel = true
+	return s.ObjectBase.TakeDamage(d)
+}
+
+func (s *SpaceShip) IsShieldUp() bool {
+	return s.HasEffect(design.Invulnerable) || s.ObjectBase.IsShieldUp()
 }
 
 func (s *SpaceShip) GetType() string {
 	return "spaceship"
 }
 
+func (s *SpaceShip) GetLayer() game.Layer {
+	return game.LayerActors
+}
+
+func (s *SpaceShip) GetPhase() game.Phase {
+	return game.PhaseSimulate
+}
+
 func (s *SpaceShip) GetCurrent() int {
 	return s.Health
 }
 
-func (s *SpaceShip) shootBeam(gc *game.GameContext) {
+func (s *SpaceShip) shootBeam(gc *game.GameContext, delta float64) {
 	x := int(s.Position.GetX()) + s.Width/2
 	y := int(s.Position.Y)
-	s.InitBeam(base.Point{X: x, Y: y}, base.Up, gc.Sounds)
+	if s.ActiveWeapon == 0 {
+		if s.mouseDown {
+			s.InitBeam(base.Point{X: x, Y: y}, base.Up, gc.Sounds)
+		}
+		return
+	}
+	s.Weapons[s.ActiveWeapon-1].Fire(base.Point{X: x, Y: y}, s.mouseDown, delta, gc.Sounds)
 }
 
 func (s *SpaceShip) GetMax() int {
@@ -27,7 +27,7 @@ func NewStarsProducer(cfg game.GameConfig) *StarProducer {
 
 func (s *StarProducer) Deploy() {
 	w, _ := base.GetSize()
-	xPos := rand.Intn(w)
+	xPos := rand.Intn(max(w, 1))
 
 	randSpeed := rand.Float64()*float64(max(s.Cfg.StarsConfig.Speed, 15)) + 10
 
@@ -106,3 +106,11 @@ func (s *StarProducer) InputEvents(event tcell.Event, gc *game.GameContext) {
 func (s *StarProducer) GetType() string {
 	return "star"
 }
+
+func (s *StarProducer) GetLayer() game.Layer {
+	return game.LayerBackground
+}
+
+func (s *StarProducer) GetPhase() game.Phase {
+	return game.PhaseRender
+}
@@ -0,0 +1,290 @@
+package entities
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/omar0ali/spaceinvaders-game-cli/game"
+)
+
+// sampleInterval is how often the score and health are recorded, in seconds.
+const sampleInterval = 1.0
+
+type Sample struct {
+	Time   float64 `json:"time"`
+	Score  int     `json:"score"`
+	Health int     `json:"health"`
+}
+
+type BossFight struct {
+	Name     string  `json:"name"`
+	Duration float64 `json:"duration"`
+	Defeated bool    `json:"defeated"`
+}
+
+// UpgradeDPS is the damage per second dealt after an upgrade was chosen, until the next one.
+type UpgradeDPS struct {
+	Upgrade  string  `json:"upgrade"`
+	Damage   int     `json:"damage"`
+	Duration float64 `json:"duration"`
+	DPS      float64 `json:"dps"`
+}
+
+// RunStats is the report of a single run, shown on the game over screen.
+type RunStats struct {
+	Ship          string         `json:"ship"`
+	Duration      float64        `json:"duration"`
+	Score         int            `json:"score"`
+	Level         int            `json:"level"`
+	BeamsFired    int            `json:"beams_fired"`
+	BeamsHit      int            `json:"beams_hit"`
+	Accuracy      float64        `json:"accuracy"`
+	DamageDealt   int            `json:"damage_dealt"`
+	KillsByDesign map[string]int `json:"kills_by_design"`
+	Pickups       map[string]int `json:"pickups"`
+	Upgrades      []string       `json:"upgrades"` // in the order they were chosen
+	DPSByUpgrade  []UpgradeDPS   `json:"dps_by_upgrade"`
+	BossFights    []BossFight    `json:"boss_fights"`
+	Samples       []Sample       `json:"samples"`
+
+	nextSample   float64
+	segmentStart float64 // start of the current dps segment
+	segmentDealt int     // damage dealt when the segment started
+	bossStart    float64
+}
+
+func NewRunStats() RunStats {
+	return RunStats{
+		KillsByDesign: map[string]int{},
+		Pickups:       map[string]int{},
+	}
+}
+
+// subscribeStats records the run events into the stats of the spaceship.
+func (s *SpaceShip) subscribeStats(gc *game.GameContext) {
+	game.Subscribe(gc, func(e game.EnemyKilled) {
+		s.Stats.KillsByDesign[e.Name]++
+		if e.Boss {
+			s.Stats.endBossFight(true)
+		}
+	})
+	game.Subscribe(gc, func(e game.PickupCollected) {
+		s.Stats.Pickups[e.Name]++
+	})
+	game.Subscribe(gc, func(e game.BossSpawned) {
+		s.Stats.BossFights = append(s.Stats.BossFights, BossFight{Name: e.Name})
+		s.Stats.bossStart = s.Stats.Duration
+	})
+	game.Subscribe(gc, func(e game.UpgradeChosen) {
+		s.Stats.endSegment(s.damageDealt())
+		s.Stats.Upgrades = append(s.Stats.Upgrades, e.Name)
+	})
+	game.Subscribe(gc, func(e game.GameOver) {
+		s.Stats.endBossFight(false)
+		s.Stats.endSegment(s.damageDealt())
+		s.Stats.Score = e.Score
+		s.Stats.Level = e.Level
+		s.Stats.BeamsFired, s.Stats.BeamsHit = 0, 0
+		for _, g := range s.guns() {
+			record := g.GetRecord()
+			s.Stats.BeamsFired += record.Fired
+			s.Stats.BeamsHit += record.Hits
+		}
+		if s.Stats.BeamsFired > 0 {
+			s.Stats.Accuracy = float64(s.Stats.BeamsHit) / float64(s.Stats.BeamsFired)
+		}
+		s.Stats.DamageDealt = s.damageDealt()
+	})
+}
+
+func (s *SpaceShip) damageDealt() int {
+	dealt := 0
+	for _, g := range s.guns() {
+		dealt += g.GetRecord().Damage
+	}
+	return dealt
+}
+
+// Update records the samples, delta only counts while the game is running.
+func (r *RunStats) Update(delta float64, score, health int) {
+	r.Duration += delta
+	if r.Duration < r.nextSample {
+		return
+	}
+	r.nextSample = r.Duration + sampleInterval
+	r.Samples = append(r.Samples, Sample{Time: r.Duration, Score: score, Health: health})
+}
+
+func (r *RunStats) endBossFight(defeated bool) {
+	if n := len(r.BossFights); n > 0 && r.BossFights[n-1].Duration == 0 {
+		r.BossFights[n-1].Duration = r.Duration - r.bossStart
+		r.BossFights[n-1].Defeated = defeated
+	}
+}
+
+func (r *RunStats) endSegment(dealt int) {
+	upgrade := "Base"
+	if n := len(r.Upgrades); n > 0 {
+		upgrade = r.Upgrades[n-1]
+	}
+	segment := UpgradeDPS{
+		Upgrade:  upgrade,
+		Damage:   dealt - r.segmentDealt,
+		Duration: r.Duration - r.segmentStart,
+	}
+	if segment.Duration > 0 {
+		segment.DPS = float64(segment.Damage) / segment.Duration
+	}
+	r.DPSByUpgrade = append(r.DPSByUpgrade, segment)
+	r.segmentStart, r.segmentDealt = r.Duration, dealt
+}
+
+// GetReport returns the lines displayed on the game over screen.
+func (r *RunStats) GetReport() []string {
+	lines := []string{
+		fmt.Sprintf("Spaceship: %s", r.Ship),
+		fmt.Sprintf("Time: %02d:%02d", int(r.Duration)/60, int(r.Duration)%60),
+		fmt.Sprintf("Score: %d Level: %d", r.Score, r.Level),
+		fmt.Sprintf("Accuracy: %.1f%% (%d/%d beams)", r.Accuracy*100, r.BeamsHit, r.BeamsFired),
+		fmt.Sprintf("Damage Dealt: %d", r.DamageDealt),
+		"",
+		"Kills:",
+	}
+	lines = append(lines, sortedCounts(r.KillsByDesign)...)
+
+	lines = append(lines, "", "Pickups:")
+	lines = append(lines, sortedCounts(r.Pickups)...)
+
+	lines = append(lines, "", "Upgrades (in order):")
+	if len(r.Upgrades) == 0 {
+		lines = append(lines, "- None")
+	}
+	for i, u := range r.Upgrades {
+		lines = append(lines, fmt.Sprintf("%d. %s", i+1, u))
+	}
+
+	lines = append(lines, "", "DPS by upgrade:")
+	for _, d := range r.DPSByUpgrade {
+		lines = append(lines, fmt.Sprintf("- %s: %.1f dps (%d dmg in %.fs)", d.Upgrade, d.DPS, d.Damage, d.Duration))
+	}
+
+	lines = append(lines, "", "Boss fights:")
+	if len(r.BossFights) == 0 {
+		lines = append(lines, "- None")
+	}
+	for _, b := range r.BossFights {
+		result := "not defeated"
+		if b.Defeated {
+			result = "defeated"
+		}
+		lines = append(lines, fmt.Sprintf("- %s %s in %.fs", b.Name, result, b.Duration))
+	}
+
+	lines = append(lines, "", "Score / Health over time:")
+	for i, sample := range r.Samples {
+		if i%30 == 0 { // every 30 seconds, the full series is in the export
+			lines = append(lines, fmt.Sprintf("- %02d:%02d score %d health %d",
+				int(sample.Time)/60, int(sample.Time)%60, sample.Score, sample.Health))
+		}
+	}
+	return lines
+}
+
+// sortedKeys sorts by the highest count first.
+func sortedKeys(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] == counts[keys[j]] {
+			return keys[i] < keys[j]
+		}
+		return counts[keys[i]] > counts[keys[j]]
+	})
+	return keys
+}
+
+func sortedCounts(counts map[string]int) []string {
+	if len(counts) == 0 {
+		return []string{"- None"}
+	}
+	var lines []string
+	for _, k := range sortedKeys(counts) {
+		lines = append(lines, fmt.Sprintf("- %s x%d", k, counts[k]))
+	}
+	return lines
+}
+
+// Export writes the report as JSON and CSV next to the game executable.
+func (r *RunStats) Export() (string, error) {
+	dir := "."
+	if exe, err := os.Executable(); err == nil {
+		dir = filepath.Dir(exe)
+	}
+	name := filepath.Join(dir, "report-"+time.Now().Format("20060102-150405"))
+
+	data, err := json.MarshalIndent(r, "", "    ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(name+".json", data, 0o644); err != nil {
+		return "", err
+	}
+
+	f, err := os.Create(name + ".csv")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if err := csv.NewWriter(f).WriteAll(r.csvRecords()); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// csvRecords flattens the report into section,name,value rows.
+func (r *RunStats) csvRecords() [][]string {
+	itoa := strconv.Itoa
+	ftoa := func(f float64) string { return strconv.FormatFloat(f, 'f', 2, 64) }
+
+	records := [][]string{
+		{"section", "name", "value"},
+		{"summary", "ship", r.Ship},
+		{"summary", "duration", ftoa(r.Duration)},
+		{"summary", "score", itoa(r.Score)},
+		{"summary", "level", itoa(r.Level)},
+		{"summary", "beams_fired", itoa(r.BeamsFired)},
+		{"summary", "beams_hit", itoa(r.BeamsHit)},
+		{"summary", "accuracy", ftoa(r.Accuracy)},
+		{"summary", "damage_dealt", itoa(r.DamageDealt)},
+	}
+	for _, name := range sortedKeys(r.KillsByDesign) {
+		records = append(records, []string{"kills", name, itoa(r.KillsByDesign[name])})
+	}
+	for _, name := range sortedKeys(r.Pickups) {
+		records = append(records, []string{"pickups", name, itoa(r.Pickups[name])})
+	}
+	for i, u := range r.Upgrades {
+		records = append(records, []string{"upgrades", itoa(i + 1), u})
+	}
+	for _, d := range r.DPSByUpgrade {
+		records = append(records, []string{"dps_by_upgrade", d.Upgrade, ftoa(d.DPS)})
+	}
+	for _, b := range r.BossFights {
+		records = append(records, []string{"boss_fights", b.Name, ftoa(b.Duration)})
+	}
+	for _, sample := range r.Samples {
+		records = append(records,
+			[]string{"score", ftoa(sample.Time), itoa(sample.Score)},
+			[]string{"health", ftoa(sample.Time), itoa(sample.Health)},
+		)
+	}
+	return records
+}
@@ -0,0 +1,197 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/omar0ali/spaceinvaders-game-cli/entities/ui"
+	"github.com/omar0ali/spaceinvaders-game-cli/game"
+	"github.com/omar0ali/spaceinvaders-game-cli/game/design"
+)
+
+type Talents struct {
+	Tree    design.TalentTreeDesign
+	Ranks   map[string]int
+	Learned []string // talent ids in the order they were picked
+}
+
+func NewTalents(designs *design.LoadedDesigns, ship string) Talents {
+	t := Talents{Ranks: map[string]int{}}
+	for _, tree := range designs.ListOfTalents {
+		if tree.Ship == ship {
+			t.Tree = tree
+			return t
+		}
+		if tree.Ship == "" { // default tree unless the ship has its own
+			t.Tree = tree
+		}
+	}
+	return t
+}
+
+func (t *Talents) Rank(id string) int {
+	return t.Ranks[id]
+}
+
+func (t *Talents) find(id string) *design.TalentDesign {
+	for i := range t.Tree.Talents {
+		if t.Tree.Talents[i].ID == id {
+			return &t.Tree.Talents[i]
+		}
+	}
+	return nil
+}
+
+// IsExcluded reports whether a talent from another branch was learned.
+func (t *Talents) IsExcluded(talent design.TalentDesign) bool {
+	for _, other := range t.Tree.Talents {
+		if t.Rank(other.ID) == 0 {
+			continue
+		}
+		if slices.Contains(talent.Excludes, other.ID) || slices.Contains(other.Excludes, talent.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *Talents) IsAvailable(talent design.TalentDesign) bool {
+	if t.Rank(talent.ID) >= talent.MaxRank || t.IsExcluded(talent) {
+		return false
+	}
+	for _, id := range talent.Requires {
+		if t.Rank(id) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *Talents) HasAvailable() bool {
+	for _, talent := range t.Tree.Talents {
+		if t.IsAvailable(talent) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTalentsSummary lists the learned talents, used in the pause menu.
+func (t *Talents) GetTalentsSummary() []string {
+	var lines []string
+	for _, talent := range t.Tree.Talents {
+		if rank := t.Rank(talent.ID); rank > 0 {
+			lines = append(lines, fmt.Sprintf("- %s %d/%d", talent.Name, rank, talent.MaxRank))
+		}
+	}
+	if len(lines) == 0 {
+		return []string{"- No talents learned yet."}
+	}
+	return lines
+}
+
+func (s *SpaceShip) findAbility(name string) (design.AbilityDesign, bool) {
+	for _, a := range s.LoadedDesigns.ListOfAbilities {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return design.AbilityDesign{}, false
+}
+
+// LearnTalent applies the ability of the talent and increases its rank.
+func (s *SpaceShip) LearnTalent(id string, gc *game.GameContext) bool {
+	talent := s.Talents.find(id)
+	if talent == nil || !s.Talents.IsAvailable(*talent) {
+		return false
+	}
+	ability, ok := s.findAbility(talent.Ability)
+	if !ok {
+		game.Logger(game.SubsystemGame).Error("talent ability not found", "talent", talent.ID, "ability", talent.Ability)
+		return false
+	}
+	if !s.ApplyAbility(ability.Effect, ability.Effect.MaxValue) {
+		SetStatus("Ability Maxed Out!", gc)
+		return false
+	}
+	s.Talents.Ranks[id]++
+	s.Talents.Learned = append(s.Talents.Learned, id)
+	game.Publish(gc, game.UpgradeChosen{Name: talent.Name})
+	if ability.Status != "" {
+		SetStatus(ability.Status, gc)
+	}
+	return true
+}
+
+// talentTreeNodes builds the nodes of the tree layout, onLearn is called after a talent is
+// learned or the level up is skipped.
+func (s *SpaceShip) talentTreeNodes(gc *game.GameContext, onLearn func()) []*ui.TreeNode {
+	nodes := map[string]*ui.TreeNode{}
+	var list []*ui.TreeNode
+
+	for _, talent := range s.Talents.Tree.Talents {
+		desc := []string{
+			fmt.Sprintf("(*) %s [Rank %d/%d]", talent.Name, s.Talents.Rank(talent.ID), talent.MaxRank),
+		}
+		if ability, ok := s.findAbility(talent.Ability); ok {
+			desc = append(desc, "Details: "+ability.Description)
+		}
+		if len(talent.Requires) > 0 {
+			desc = append(desc, "Requires: "+s.talentNames(talent.Requires))
+		}
+		if len(talent.Excludes) > 0 {
+			desc = append(desc, "Locks: "+s.talentNames(talent.Excludes))
+		}
+
+		id := talent.ID
+		node := ui.NewTreeNode(talent.Name, talent.Tier, talent.Column, desc, func() {
+			if s.LearnTalent(id, gc) {
+				onLearn()
+			}
+		})
+		node.Rank = s.Talents.Rank(talent.ID)
+		node.MaxRank = talent.MaxRank
+		switch {
+		case node.Rank >= node.MaxRank:
+			node.State = ui.NodeMaxed
+		case s.Talents.IsAvailable(talent):
+			node.State = ui.NodeAvailable
+		default:
+			node.State = ui.NodeLocked
+		}
+		nodes[talent.ID] = node
+		list = append(list, node)
+	}
+
+	for _, talent := range s.Talents.Tree.Talents {
+		for _, id := range talent.Requires {
+			if parent, ok := nodes[id]; ok {
+				nodes[talent.ID].Parents = append(nodes[talent.ID].Parents, parent)
+			}
+		}
+	}
+
+	// skip closes the level up when nothing left can be learned (i.e every stat is maxed)
+	rows, columns := 0, 0
+	for _, n := range list {
+		rows = max(rows, n.Row+1)
+		columns = max(columns, n.Column+1)
+	}
+	skip := ui.NewTreeNode("Skip", rows, (columns-1)/2, []string{
+		"(*) Skip",
+		"Keep playing without learning a talent.",
+	}, onLearn)
+	skip.State = ui.NodeAvailable
+	return append(list, skip)
+}
+
+func (s *SpaceShip) talentNames(ids []string) string {
+	var names []string
+	for _, id := range ids {
+		if t := s.Talents.find(id); t != nil {
+			names = append(names, t.Name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
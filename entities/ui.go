@@ -10,6 +10,7 @@ import (
 	"github.com/omar0ali/spaceinvaders-game-cli/base"
 	"github.com/omar0ali/spaceinvaders-game-cli/entities/ui"
 	"github.com/omar0ali/spaceinvaders-game-cli/game"
+	"github.com/omar0ali/spaceinvaders-game-cli/game/design"
 )
 
 var (
@@ -20,251 +21,338 @@ var (
 	mu           sync.Mutex
 )
 
+// UI draws the HUD and the notifications, the menus and the game over report are shown
+// by the scenes (scenes.go).
 type UI struct {
-	MenuScreen         bool
-	PauseScreen        bool
-	GameOverScreen     bool
-	LevelUpScreen      bool
-	SpaceShipSelection bool
-	timeElapsed        float64
-	exitCha            chan struct{}
-	cfg                game.GameConfig
+	timeElapsed float64
+	exitCha     chan struct{}
+	cfg         game.GameConfig
 }
 
-func NewUI(gc *game.GameContext, cfg game.GameConfig, exitCha chan struct{}) *UI {
+func NewUI(cfg game.GameConfig, exitCha chan struct{}) *UI {
 	nextMinute = 0
 
-	u := &UI{
-		MenuScreen:         true,
-		PauseScreen:        false,
-		GameOverScreen:     false,
-		LevelUpScreen:      false,
-		SpaceShipSelection: false,
-		exitCha:            exitCha,
-		cfg:                cfg,
+	return &UI{
+		exitCha: exitCha,
+		cfg:     cfg,
 	}
+}
 
-	if u.MenuScreen {
-		if layout, ok := gc.FindEntity("layout").(*ui.UISystem); ok {
-			boxes := []*ui.Box{
-				ui.NewUIBox(
-					[]string{
-						"Start New Game",
-					}, ui.StartGameDesc,
-					func() {
-						// here we should start the game
-						SetStatus("Select a Spaceship", gc)
-						u.SpaceShipSelection = true
-						if s, ok := gc.FindEntity("spaceship").(*SpaceShip); ok {
-							var boxes []*ui.Box
-							for i, shipDesign := range s.LoadedDesigns.ListOfSpaceships {
-								descriptions := []string{
-									fmt.Sprintf("- [%s]", shipDesign.Name),
-									fmt.Sprintf("* HP:         %d", shipDesign.EntityHealth),
-									fmt.Sprintf("* Gun PWD:    %d", shipDesign.GunPower),
-									fmt.Sprintf("* Gun CAP:    %d", shipDesign.GunCap),
-									fmt.Sprintf("* Gun SPD:    %d", shipDesign.GunSpeed),
-									fmt.Sprintf("* Gun CD:     %d ms", shipDesign.GunCooldown),
-									fmt.Sprintf("* Gun RLD CD: %d ms", shipDesign.GunReloadCooldown),
-								}
-
-								boxes = append(boxes, ui.NewUIBox(
-									shipDesign.Shape,
-									descriptions,
-									func() {
-										name := s.SpaceshipSelection(i)
-										SetStatus(fmt.Sprintf("%s Selected", name), gc)
-										u.SpaceShipSelection = false
-										layout.SetLayout(nil)
-									},
-								))
+// mainMenu shows the main menu, entered by the main menu scene.
+func (u *UI) mainMenu(gc *game.GameContext) {
+	if layout, ok := gc.FindEntity("layout").(*ui.UISystem); ok {
+		boxes := []*ui.Box{
+			ui.NewUIBox(
+				[]string{
+					"Start New Game",
+				}, ui.StartGameDesc,
+				func() {
+					SetStatus("Select a Spaceship", gc)
+					gc.Scenes.Replace(gc, u.shipSelectScene())
+				},
+			),
+			ui.NewUIBox(
+				[]string{
+					"Compendium",
+				},
+				[]string{
+					"Scan the battlefield: ships, asteroids and abilities.",
+				}, func() {
+					// init items for the menu
+					abilitiesItems := make([]*ui.Box, 0)
+					spaceshipsItems := make([]*ui.Box, 0)
+					asteroidsItems := make([]*ui.Box, 0)
+					alienShipsItems := make([]*ui.Box, 0)
+					bossShipsItems := make([]*ui.Box, 0)
+					modifiersItems := make([]*ui.Box, 0)
+					weaponsItems := make([]*ui.Box, 0)
+
+					// Load designs for each items
+					if ship, ok := gc.FindEntity("spaceship").(*SpaceShip); ok {
+						for _, i := range ship.LoadedDesigns.ListOfAbilities {
+							descriptions := []string{
+								fmt.Sprintf("- [%s]", i.Name),
+								fmt.Sprintf("* Description:    %s", i.Description),
+								fmt.Sprintf("* Status:    %s", i.Status),
 							}
-							layout.SetLayout(
-								ui.InitLayout(21, 10, boxes...),
+
+							abilitiesItems = append(
+								abilitiesItems,
+								ui.NewUIBox(i.Shape, descriptions, nil), // using hover
 							)
 						}
-						u.MenuScreen = false
-					},
-				),
-				ui.NewUIBox(
-					[]string{
-						"Compendium",
-					},
-					[]string{
-						"Scan the battlefield: ships, asteroids and abilities.",
-					}, func() {
-						// init items for the menu
-						abilitiesItems := make([]*ui.Box, 0)
-						spaceshipsItems := make([]*ui.Box, 0)
-						asteroidsItems := make([]*ui.Box, 0)
-						alienShipsItems := make([]*ui.Box, 0)
-						bossShipsItems := make([]*ui.Box, 0)
-						modifiersItems := make([]*ui.Box, 0)
-
-						// Load designs for each items
-						if ship, ok := gc.FindEntity("spaceship").(*SpaceShip); ok {
-							for _, i := range ship.LoadedDesigns.ListOfAbilities {
-								descriptions := []string{
-									fmt.Sprintf("- [%s]", i.Name),
-									fmt.Sprintf("* Description:    %s", i.Description),
-									fmt.Sprintf("* Status:    %s", i.Status),
-								}
-
-								abilitiesItems = append(
-									abilitiesItems,
-									ui.NewUIBox(i.Shape, descriptions, nil), // using hover
-								)
+						for _, i := range ship.LoadedDesigns.ListOfSpaceships {
+							descriptions := []string{
+								fmt.Sprintf("- [%s]", i.Name),
+								fmt.Sprintf("* HP:         %d", i.EntityHealth),
+								fmt.Sprintf("* Gun POW:    %d", i.GunPower),
+								fmt.Sprintf("* Gun CAP:    %d", i.GunCap),
+								fmt.Sprintf("* Gun SPD:    %d", i.GunSpeed),
+								fmt.Sprintf("* Gun CD:     %d ms", i.GunCooldown),
+								fmt.Sprintf("* Gun RLD CD: %d ms", i.GunReloadCooldown),
+								fmt.Sprintf("* Damage:     %s", damageTypeName(i.DamageType)),
+								fmt.Sprintf("* Armor:      %d", i.Armor),
+								fmt.Sprintf("* Shield:     %d", i.Shield),
 							}
-							for _, i := range ship.LoadedDesigns.ListOfSpaceships {
-								descriptions := []string{
-									fmt.Sprintf("- [%s]", i.Name),
-									fmt.Sprintf("* HP:         %d", i.EntityHealth),
-									fmt.Sprintf("* Gun POW:    %d", i.GunPower),
-									fmt.Sprintf("* Gun CAP:    %d", i.GunCap),
-									fmt.Sprintf("* Gun SPD:    %d", i.GunSpeed),
-									fmt.Sprintf("* Gun CD:     %d ms", i.GunCooldown),
-									fmt.Sprintf("* Gun RLD CD: %d ms", i.GunReloadCooldown),
-								}
-								spaceshipsItems = append(
-									spaceshipsItems,
-									ui.NewUIBox(i.Shape, descriptions, nil), // using hover
-								)
+							spaceshipsItems = append(
+								spaceshipsItems,
+								ui.NewUIBox(i.Shape, descriptions, nil), // using hover
+							)
+						}
+						for _, i := range ship.LoadedDesigns.ListOfAsteroids.Asteroids {
+							descriptions := []string{
+								fmt.Sprintf("- [%s]", i.Name),
+								fmt.Sprintf("Color:  %s", i.Color),
+								fmt.Sprintf("Health: %d", i.EntityHealth),
 							}
-							for _, i := range ship.LoadedDesigns.ListOfAsteroids.Asteroids {
-								descriptions := []string{
-									fmt.Sprintf("- [%s]", i.Name),
-									fmt.Sprintf("Color:  %s", i.Color),
-									fmt.Sprintf("Health: %d", i.EntityHealth),
-								}
 
-								asteroidsItems = append(asteroidsItems,
-									ui.NewUIBox(i.Shape, descriptions, nil))
+							asteroidsItems = append(asteroidsItems,
+								ui.NewUIBox(i.Shape, descriptions, nil))
+						}
+						for _, i := range ship.LoadedDesigns.ListOfAlienships {
+							descriptions := []string{
+								fmt.Sprintf("- [%s]", i.Name),
+								fmt.Sprintf("* HP:         %d", i.EntityHealth),
+								fmt.Sprintf("* Gun POW:    %d", i.GunPower),
+								fmt.Sprintf("* Gun CAP:    %d", i.GunCap),
+								fmt.Sprintf("* Gun SPD:    %d", i.GunSpeed),
+								fmt.Sprintf("* Gun CD:     %d ms", i.GunCooldown),
+								fmt.Sprintf("* Gun RLD CD: %d ms", i.GunReloadCooldown),
+								fmt.Sprintf("* Damage:     %s", damageTypeName(i.DamageType)),
+								fmt.Sprintf("* Armor:      %d", i.Armor),
+								fmt.Sprintf("* Shield:     %d", i.Shield),
 							}
-							for _, i := range ship.LoadedDesigns.ListOfAlienships {
-								descriptions := []string{
-									fmt.Sprintf("- [%s]", i.Name),
-									fmt.Sprintf("* HP:         %d", i.EntityHealth),
-									fmt.Sprintf("* Gun POW:    %d", i.GunPower),
-									fmt.Sprintf("* Gun CAP:    %d", i.GunCap),
-									fmt.Sprintf("* Gun SPD:    %d", i.GunSpeed),
-									fmt.Sprintf("* Gun CD:     %d ms", i.GunCooldown),
-									fmt.Sprintf("* Gun RLD CD: %d ms", i.GunReloadCooldown),
-								}
-								alienShipsItems = append(alienShipsItems,
-									ui.NewUIBox(i.Shape, descriptions, nil))
+							alienShipsItems = append(alienShipsItems,
+								ui.NewUIBox(i.Shape, descriptions, nil))
+						}
+						for _, i := range ship.LoadedDesigns.ListOfBossShips {
+							descriptions := []string{
+								fmt.Sprintf("- [%s]", i.Name),
+								fmt.Sprintf("* HP:         %d", i.EntityHealth),
+								fmt.Sprintf("* Gun POW:    %d", i.GunPower),
+								fmt.Sprintf("* Gun CAP:    %d", i.GunCap),
+								fmt.Sprintf("* Gun SPD:    %d", i.GunSpeed),
+								fmt.Sprintf("* Gun CD:     %d ms", i.GunCooldown),
+								fmt.Sprintf("* Gun RLD CD: %d ms", i.GunReloadCooldown),
+								fmt.Sprintf("* Damage:     %s", damageTypeName(i.DamageType)),
+								fmt.Sprintf("* Armor:      %d", i.Armor),
+								fmt.Sprintf("* Shield:     %d", i.Shield),
 							}
-							for _, i := range ship.LoadedDesigns.ListOfBossShips {
-								descriptions := []string{
-									fmt.Sprintf("- [%s]", i.Name),
-									fmt.Sprintf("* HP:         %d", i.EntityHealth),
-									fmt.Sprintf("* Gun POW:    %d", i.GunPower),
-									fmt.Sprintf("* Gun CAP:    %d", i.GunCap),
-									fmt.Sprintf("* Gun SPD:    %d", i.GunSpeed),
-									fmt.Sprintf("* Gun CD:     %d ms", i.GunCooldown),
-									fmt.Sprintf("* Gun RLD CD: %d ms", i.GunReloadCooldown),
-								}
-								bossShipsItems = append(bossShipsItems,
-									ui.NewUIBox(i.Shape, descriptions, nil))
+							bossShipsItems = append(bossShipsItems,
+								ui.NewUIBox(i.Shape, descriptions, nil))
+						}
+						for _, i := range ship.LoadedDesigns.ModifierDesign {
+							descriptions := []string{
+								fmt.Sprintf("- [%s]", i.Name),
+								fmt.Sprintf("* Health:     %d", i.EntityHealth),
+								fmt.Sprintf("* Modify Gun POW:     %d", i.ModifyGunPower),
+								fmt.Sprintf("* Modify Gun CAP:     %d", i.ModifyGunCap),
+								fmt.Sprintf("* Modify Gun SPD:     %d", i.ModifyGunSpeed),
+								fmt.Sprintf("* Modify Gun CD:      %d", i.ModifyGunCoolDown),
+								fmt.Sprintf("* Modify Gun CD RLD:  %d", i.ModifyGunReloadCoolDown),
+								fmt.Sprintf("* Max:     %d", i.MaxValue),
+								fmt.Sprintf("* Duration:     %ds", i.Duration/1000),
 							}
-							for _, i := range ship.LoadedDesigns.ModifierDesign {
-								descriptions := []string{
-									fmt.Sprintf("- [%s]", i.Name),
-									fmt.Sprintf("* Health:     %d", i.EntityHealth),
-									fmt.Sprintf("* Modify Gun POW:     %d", i.ModifyGunPower),
-									fmt.Sprintf("* Modify Gun CAP:     %d", i.ModifyGunCap),
-									fmt.Sprintf("* Modify Gun SPD:     %d", i.ModifyGunSpeed),
-									fmt.Sprintf("* Modify Gun CD:      %d", i.ModifyGunCoolDown),
-									fmt.Sprintf("* Modify Gun CD RLD:  %d", i.ModifyGunReloadCoolDown),
-									fmt.Sprintf("* Max:     %d", i.MaxValue),
-								}
 
-								modifiersItems = append(modifiersItems,
-									ui.NewUIBox(i.Shape, descriptions, nil))
+							modifiersItems = append(modifiersItems,
+								ui.NewUIBox(i.Shape, descriptions, nil))
+						}
+						for _, i := range ship.LoadedDesigns.ListOfWeapons {
+							descriptions := []string{
+								fmt.Sprintf("- [%s]", i.Name),
+								fmt.Sprintf("* Description: %s", i.Description),
+								fmt.Sprintf("* Gun POW:    %d", i.GunPower),
+								fmt.Sprintf("* Gun CAP:    %d", i.GunCap),
+								fmt.Sprintf("* Gun SPD:    %d", i.GunSpeed),
+								fmt.Sprintf("* Gun CD:     %d ms", i.GunCooldown),
+								fmt.Sprintf("* Gun RLD CD: %d ms", i.GunReloadCooldown),
+								fmt.Sprintf("* Damage:     %s", damageTypeName(i.DamageType)),
 							}
 
+							weaponsItems = append(weaponsItems,
+								ui.NewUIBox(i.Shape, descriptions, nil))
 						}
-						layoutCodexMenu := ui.InitCodexMenu(20, 5)
-						boxes := make([]*ui.Box, 0)
-						boxes = append(boxes,
-							ui.NewUIBox(
-								[]string{
-									"Abilities",
-								},
-								[]string{
-									"Displaying the Abilities",
-								}, func() {
-									layoutCodexMenu.SetList(abilitiesItems)
-								}),
-							ui.NewUIBox(
-								[]string{
-									"Spaceships",
-								},
-								[]string{
-									"Displaying the Spaceships",
-								}, func() {
-									layoutCodexMenu.SetList(spaceshipsItems)
-								}),
-							ui.NewUIBox(
-								[]string{
-									"Asteroids",
-								},
-								[]string{
-									"Displaying the Asteroids",
-								}, func() {
-									layoutCodexMenu.SetList(asteroidsItems)
-								}),
-							ui.NewUIBox(
-								[]string{
-									"Alienships",
-								},
-								[]string{
-									"Displaying the Alienships",
-								}, func() {
-									layoutCodexMenu.SetList(alienShipsItems)
-								}),
-							ui.NewUIBox(
-								[]string{
-									"Boss Spaceships",
-								},
-								[]string{
-									"Displaying the Boss Spaceships",
-								}, func() {
-									layoutCodexMenu.SetList(bossShipsItems)
-								}),
-							ui.NewUIBox(
-								[]string{
-									"Modifiers",
-								},
-								[]string{
-									"Displaying the Modifiers",
-								}, func() {
-									layoutCodexMenu.SetList(modifiersItems)
-								}),
-							ui.NewUIBox(
-								[]string{
-									"< Back",
-								},
-								[]string{
-									"Back to main menu.",
-								}, func() {
-									RestartGame(gc, u.cfg, u.exitCha)
-								}))
-						layoutCodexMenu.SetMenuItems(boxes)
-						layout.SetLayout(layoutCodexMenu)
-					},
-				),
-				ui.NewUIBox([]string{
-					"Quit Game",
-				}, []string{"Quit the game."}, func() {
-					base.ExitGame(exitCha)
-				}),
+
+					}
+					layoutCodexMenu := ui.InitCodexMenu(20, 5)
+					boxes := make([]*ui.Box, 0)
+					boxes = append(boxes,
+						ui.NewUIBox(
+							[]string{
+								"Abilities",
+							},
+							[]string{
+								"Displaying the Abilities",
+							}, func() {
+								layoutCodexMenu.SetList(abilitiesItems)
+							}),
+						ui.NewUIBox(
+							[]string{
+								"Spaceships",
+							},
+							[]string{
+								"Displaying the Spaceships",
+							}, func() {
+								layoutCodexMenu.SetList(spaceshipsItems)
+							}),
+						ui.NewUIBox(
+							[]string{
+								"Asteroids",
+							},
+							[]string{
+								"Displaying the Asteroids",
+							}, func() {
+								layoutCodexMenu.SetList(asteroidsItems)
+							}),
+						ui.NewUIBox(
+							[]string{
+								"Alienships",
+							},
+							[]string{
+								"Displaying the Alienships",
+							}, func() {
+								layoutCodexMenu.SetList(alienShipsItems)
+							}),
+						ui.NewUIBox(
+							[]string{
+								"Boss Spaceships",
+							},
+							[]string{
+								"Displaying the Boss Spaceships",
+							}, func() {
+								layoutCodexMenu.SetList(bossShipsItems)
+							}),
+						ui.NewUIBox(
+							[]string{
+								"Modifiers",
+							},
+							[]string{
+								"Displaying the Modifiers",
+							}, func() {
+								layoutCodexMenu.SetList(modifiersItems)
+							}),
+						ui.NewUIBox(
+							[]string{
+								"Weapons",
+							},
+							[]string{
+								"Displaying the Weapons",
+							}, func() {
+								layoutCodexMenu.SetList(weaponsItems)
+							}),
+						ui.NewUIBox(
+							[]string{
+								"Achievements",
+							},
+							[]string{
+								"Displaying the Achievements",
+							}, func() {
+								if ship, ok := gc.FindEntity("spaceship").(*SpaceShip); ok {
+									layoutCodexMenu.SetList(achievementsItems(ship.LoadedDesigns, gc.Profile))
+								}
+							}),
+						ui.NewUIBox(
+							[]string{
+								"< Back",
+							},
+							[]string{
+								"Back to main menu.",
+							}, func() {
+								RestartGame(gc, u.cfg, u.exitCha)
+							}))
+					layoutCodexMenu.SetMenuItems(boxes)
+					layout.SetLayout(layoutCodexMenu)
+				},
+			),
+			ui.NewUIBox(
+				[]string{
+					"Hangar",
+				},
+				[]string{
+					"Spend credits on new spaceships and permanent upgrades.",
+				}, func() {
+					if s, ok := gc.FindEntity("spaceship").(*SpaceShip); ok {
+						u.HangarMenu(gc, layout, s.LoadedDesigns, func() { u.mainMenu(gc) })
+					}
+				},
+			),
+			ui.NewUIBox(
+				[]string{
+					"Settings",
+				},
+				[]string{
+					"Change the volume of the sounds, [M] mutes everything.",
+				}, func() {
+					u.SettingsMenu(gc, layout, func() { u.mainMenu(gc) })
+				},
+			),
+			ui.NewUIBox([]string{
+				"Quit Game",
+			}, []string{"Quit the game."}, func() {
+				base.ExitGame(u.exitCha)
+			}),
+		}
+		layout.SetLayout(
+			ui.InitMainMenu(20, 5, boxes...),
+		)
+	}
+}
+
+// shipSelection shows the spaceships to pick from, entered by the ship select scene.
+func (u *UI) shipSelection(gc *game.GameContext) {
+	layout, ok := gc.FindEntity("layout").(*ui.UISystem)
+	if !ok {
+		return
+	}
+	if s, ok := gc.FindEntity("spaceship").(*SpaceShip); ok {
+		var boxes []*ui.Box
+		for i, shipDesign := range s.LoadedDesigns.ListOfSpaceships {
+			descriptions := []string{
+				fmt.Sprintf("- [%s]", shipDesign.Name),
+				fmt.Sprintf("* HP:         %d", shipDesign.EntityHealth),
+				fmt.Sprintf("* Gun PWD:    %d", shipDesign.GunPower),
+				fmt.Sprintf("* Gun CAP:    %d", shipDesign.GunCap),
+				fmt.Sprintf("* Gun SPD:    %d", shipDesign.GunSpeed),
+				fmt.Sprintf("* Gun CD:     %d ms", shipDesign.GunCooldown),
+				fmt.Sprintf("* Gun RLD CD: %d ms", shipDesign.GunReloadCooldown),
+				fmt.Sprintf("* Damage:     %s", damageTypeName(shipDesign.DamageType)),
+				fmt.Sprintf("* Armor:      %d", shipDesign.Armor),
+				fmt.Sprintf("* Shield:     %d", shipDesign.Shield),
+			}
+
+			unlocked := gc.Profile.IsUnlocked(shipDesign.Name, shipDesign.UnlockCost)
+			if !unlocked {
+				descriptions = append(descriptions,
+					fmt.Sprintf("* LOCKED:     %d credits (Hangar)", shipDesign.UnlockCost))
 			}
-			layout.SetLayout(
-				ui.InitMainMenu(20, 5, boxes...),
-			)
+
+			boxes = append(boxes, ui.NewUIBox(
+				shipDesign.Shape,
+				descriptions,
+				func() {
+					if !unlocked {
+						SetStatus(fmt.Sprintf("%s is locked, unlock it in the Hangar", shipDesign.Name), gc)
+						return
+					}
+					name := s.SpaceshipSelection(i)
+					s.ApplyUpgrades(gc.Profile)
+					s.Achieve(gc, design.EventShipUsed, name, 1)
+					SetStatus(fmt.Sprintf("%s Selected", name), gc)
+					gc.Scenes.Replace(gc, u.playingScene())
+				},
+			))
 		}
+		layout.SetLayout(
+			ui.InitLayout(21, 10, boxes...),
+		)
+	}
+}
+
+func damageTypeName(t design.DamageType) string {
+	if t == "" {
+		return design.Kinetic
 	}
-	return u
+	return t
 }
 
 func (u *UI) Draw(gc *game.GameContext) {
@@ -280,7 +368,7 @@ func (u *UI) Draw(gc *game.GameContext) {
 	minutes = int(u.timeElapsed) / 60
 	seconds = int(u.timeElapsed) % 60
 
-	if !u.MenuScreen && !u.SpaceShipSelection {
+	if gc.Scenes.Has(game.ScenePlaying) || gc.Scenes.Has(game.SceneGameOver) {
 		w, h := base.GetSize()
 		// draw a line
 
@@ -288,8 +376,11 @@ func (u *UI) Draw(gc *game.GameContext) {
 			base.SetContent(i, h-2, tcell.RuneHLine)
 		}
 
-		// show controls at the bottom of the screen
-		controlsUI := []rune("[LM] Shoot Beams ◆ [E] Consume Health Kit ◆ [R] Reload Gun ◆ [P] Pause Game ◆ [Ctrl+R] Restart Game ◆ [Ctrl+Q] Quit")
+		// show controls at the bottom of the screen, shorter ones when they do not fit
+		controlsUI := []rune("[LM] Shoot Beams ◆ [1-5] Switch Weapon ◆ [B] Bomb ◆ [E] Consume Health Kit ◆ [R] Reload Gun ◆ [P] Pause Game ◆ [Ctrl+R] Restart Game ◆ [Ctrl+Q] Quit")
+		if len(controlsUI) > w {
+			controlsUI = []rune("[LM] Shoot ◆ [1-5] Weapon ◆ [B] Bomb ◆ [E] Kit ◆ [R] Reload ◆ [P] Pause")
+		}
 		for i, r := range controlsUI {
 			base.SetContentWithStyle(w/2-(len(controlsUI)/2)+i, h-1, r, whiteColor)
 		}
@@ -326,6 +417,7 @@ func (u *UI) Draw(gc *game.GameContext) {
 				}
 				// display spacehsip details - Also drop a health kit every minute
 				s.UISpaceshipData(gc)
+				s.UIEffectsData(gc)
 			}
 		}, greenColor)
 
@@ -335,48 +427,20 @@ func (u *UI) Draw(gc *game.GameContext) {
 		}
 
 	}
-
-	// game over ui
-	if u.GameOverScreen {
-		if s, ok := gc.FindEntity("spaceship").(*SpaceShip); ok {
-			u.MessageBox(base.GetCenterPoint(),
-				fmt.Sprintf(`
-			Taken damage from:
-			%v
-
-			Killed By:
-			%s Level: %d
-
-			Thank you for playing :)
-			---------------------------------------
-			Would you like to play again?
-			[Ctrl+R] To Restart.
-			[Ctrl+Q] To Quit.
-			`, strings.Join(s.GetRegisteredHits(), "\n"), s.KilledBy.Name, s.KilledBy.Power),
-				"Game Over",
-			)
-		}
-	}
 }
 
 func (u *UI) Update(gc *game.GameContext, delta float64) {
-	if u.MenuScreen || u.PauseScreen || u.GameOverScreen || u.LevelUpScreen || u.SpaceShipSelection {
-		gc.Halt = true
-	} else {
-		gc.Halt = false
+	if !gc.Scenes.Halted() {
 		u.timeElapsed += delta
 	}
 }
 
 func (u *UI) InputEvents(events tcell.Event, gc *game.GameContext) {
-	switch ev := events.(type) {
-	case *tcell.EventKey:
-		if ev.Rune() == 'p' || ev.Rune() == 'P' || ev.Key() == tcell.KeyESC {
-			if u.MenuScreen || u.GameOverScreen || u.SpaceShipSelection || u.LevelUpScreen { // skip
-				return
-			}
-			gc.Sounds.PlaySound("8-bit-game-sfx-sound-select.mp3", -1)
-			u.PauseGame(gc)
+	if ev, ok := events.(*tcell.EventKey); ok && (ev.Rune() == 'm' || ev.Rune() == 'M') {
+		if gc.Sounds.ToggleMute() {
+			SetStatus("Sound muted", gc)
+		} else {
+			SetStatus("Sound unmuted", gc)
 		}
 	}
 }
@@ -385,6 +449,14 @@ func (u *UI) GetType() string {
 	return "ui"
 }
 
+func (u *UI) GetLayer() game.Layer {
+	return game.LayerHUD
+}
+
+func (u *UI) GetPhase() game.Phase {
+	return game.PhaseInput
+}
+
 func (u *UI) MessageBox(origin base.Point, message string, title string) {
 	padding := 2
 	wrappedLines := u.wrapText(message)
@@ -505,9 +577,54 @@ func DrawBoxedText(text string) {
 	})
 }
 
+// DrawResizeWarning replaces the game while the terminal is smaller than the minimum size.
+// It is centred in the terminal, the letterboxed playfield could be too small to show it.
+func DrawResizeWarning() {
+	w, h := base.GetTerminalSize()
+	minW, minH := base.GetMinSize()
+	lines := []string{
+		fmt.Sprintf("Terminal too small: %dx%d", w, h),
+		fmt.Sprintf("Resize to at least %dx%d", minW, minH),
+	}
+
+	width := 0
+	for _, line := range lines {
+		width = max(width, len(line)+4)
+	}
+	height := len(lines) + 2
+	x0, y0 := max((w-width)/2, 0), max((h-height)/2, 0)
+
+	style := base.StyleIt(tcell.ColorWhite)
+	for y := range height {
+		for x := range width {
+			ch := ' '
+			switch {
+			case x == 0 && y == 0:
+				ch = tcell.RuneULCorner
+			case x == width-1 && y == 0:
+				ch = tcell.RuneURCorner
+			case x == 0 && y == height-1:
+				ch = tcell.RuneLLCorner
+			case x == width-1 && y == height-1:
+				ch = tcell.RuneLRCorner
+			case y == 0 || y == height-1:
+				ch = tcell.RuneHLine
+			case x == 0 || x == width-1:
+				ch = tcell.RuneVLine
+			}
+			base.SetTerminalContent(x0+x, y0+y, ch, style)
+		}
+	}
+	for row, line := range lines {
+		for col, r := range line {
+			base.SetTerminalContent(x0+2+col, y0+1+row, r, style)
+		}
+	}
+}
+
 func SetStatus(text string, gc *game.GameContext) {
 	mu.Lock()
-	gc.Sounds.PlaySound("8-bit-game-sfx-notification.mp3", 0)
+	gc.Sounds.PlaySound("ui_notification")
 	listOfStatus = append(listOfStatus, text) // safe add
 	mu.Unlock()
 
@@ -561,99 +678,119 @@ func DrawRectStatus(text string, y int) {
 	})
 }
 
-func (u *UI) PauseGame(gc *game.GameContext) {
-	Pausing := func(layout *ui.UISystem) {
-		if u.PauseScreen {
-			layout.SetLayout(nil)
-			done := make(chan struct{})
-			SetStatus("Get ready! Resuming in 3 seconds", gc)
-			base.DoOnce(3*time.Second, func() {
-				u.PauseScreen = false
-			}, done)
-		} else {
-			u.PauseScreen = true
-		}
+// resume hides the pause menu, the paused scene is popped after a countdown.
+func (u *UI) resume(gc *game.GameContext) {
+	if layout, ok := gc.FindEntity("layout").(*ui.UISystem); ok {
+		layout.SetLayout(nil)
 	}
+	done := make(chan struct{})
+	SetStatus("Get ready! Resuming in 3 seconds", gc)
+	base.DoOnce(3*time.Second, func() {
+		gc.Scenes.Pop(gc, game.ScenePaused)
+	}, done)
+}
+
+// pauseMenu shows the pause menu, entered by the paused scene.
+func (u *UI) pauseMenu(gc *game.GameContext) {
 	if layout, ok := gc.FindEntity("layout").(*ui.UISystem); ok {
-		Pausing(layout)
 		var spaceship *SpaceShip
 		if ship, ok := gc.FindEntity("spaceship").(*SpaceShip); ok {
 			spaceship = ship
 		}
-		if u.PauseScreen {
-			boxes := []*ui.Box{
-				ui.NewUIBox(
-					[]string{
-						"Continue",
-					},
-					[]string{
-						"Continue the game.",
-					}, func() {
-						Pausing(layout)
-					},
-				),
-				ui.NewUIBox(
-					[]string{
-						"My Spaceship",
-					}, []string{
-						fmt.Sprintf("[%s] - [Level: %d]", spaceship.SelectedSpaceship.Name, spaceship.Level),
-						"---------------------------------",
-						fmt.Sprintf("Gun Capacity:         %d +(%d) -> %d",
-							spaceship.SelectedSpaceship.GunCap,
-							spaceship.GetCapacity()-spaceship.SelectedSpaceship.GunCap,
-							spaceship.GetCapacity(),
-						),
-						fmt.Sprintf("Gun Speed:            %d +(%d) -> %d",
-							spaceship.SelectedSpaceship.GunSpeed,
-							spaceship.GetSpeed()-spaceship.SelectedSpaceship.GunSpeed,
-							spaceship.GetSpeed(),
-						),
-						fmt.Sprintf("Gun Power:            %d +(%d) -> %d",
-							spaceship.SelectedSpaceship.GunPower,
-							spaceship.GetPower()-spaceship.SelectedSpaceship.GunPower,
-							spaceship.GetPower(),
-						),
-						fmt.Sprintf("Gun Cooldown:         %d +(%d) -> %d",
-							spaceship.SelectedSpaceship.GunCooldown,
-							int(spaceship.GetCooldown())-spaceship.SelectedSpaceship.GunCooldown,
-							spaceship.GetCooldown(),
-						),
-						fmt.Sprintf("Gun Reload Cooldown:  %d +(%d) -> %d",
-							spaceship.SelectedSpaceship.GunReloadCooldown,
-							int(spaceship.GetReloadCooldown())-spaceship.SelectedSpaceship.GunReloadCooldown,
-							spaceship.GetReloadCooldown(),
-						),
-						fmt.Sprintf("Spaceship Health:     %d +(%d) -> %d",
-							spaceship.SelectedSpaceship.EntityHealth,
-							spaceship.MaxHealth-spaceship.SelectedSpaceship.EntityHealth,
-							spaceship.MaxHealth,
-						),
-					}, func() {
-
-					},
-				),
-				ui.NewUIBox(
-					[]string{
-						"Restart",
-					},
-					[]string{
-						"Return to Main Menu.",
-					},
-					func() {
-						RestartGame(gc, u.cfg, u.exitCha)
-					},
-				),
-				ui.NewUIBox([]string{
-					"Quit Game",
-				}, []string{"Exit the game."}, func() {
-					base.ExitGame(u.exitCha)
-				}),
-			}
-			menuUi := ui.InitMainMenu(20, 5, boxes...)
-			menuUi.SelectedDesc = []string{"Paused Game"}
-			layout.SetLayout(menuUi)
-		} else {
-			layout.SetLayout(nil)
+		boxes := []*ui.Box{
+			ui.NewUIBox(
+				[]string{
+					"Continue",
+				},
+				[]string{
+					"Continue the game.",
+				}, func() {
+					u.resume(gc)
+				},
+			),
+			ui.NewUIBox(
+				[]string{
+					"My Spaceship",
+				}, append([]string{
+					fmt.Sprintf("[%s] - [Level: %d]", spaceship.SelectedSpaceship.Name, spaceship.Level),
+					"---------------------------------",
+					fmt.Sprintf("Gun Capacity:         %d +(%d) -> %d",
+						spaceship.SelectedSpaceship.GunCap,
+						spaceship.GetCapacity()-spaceship.SelectedSpaceship.GunCap,
+						spaceship.GetCapacity(),
+					),
+					fmt.Sprintf("Gun Speed:            %d +(%d) -> %d",
+						spaceship.SelectedSpaceship.GunSpeed,
+						spaceship.GetSpeed()-spaceship.SelectedSpaceship.GunSpeed,
+						spaceship.GetSpeed(),
+					),
+					fmt.Sprintf("Gun Power:            %d +(%d) -> %d",
+						spaceship.SelectedSpaceship.GunPower,
+						spaceship.GetPower()-spaceship.SelectedSpaceship.GunPower,
+						spaceship.GetPower(),
+					),
+					fmt.Sprintf("Gun Cooldown:         %d +(%d) -> %d",
+						spaceship.SelectedSpaceship.GunCooldown,
+						int(spaceship.GetCooldown())-spaceship.SelectedSpaceship.GunCooldown,
+						spaceship.GetCooldown(),
+					),
+					fmt.Sprintf("Gun Reload Cooldown:  %d +(%d) -> %d",
+						spaceship.SelectedSpaceship.GunReloadCooldown,
+						int(spaceship.GetReloadCooldown())-spaceship.SelectedSpaceship.GunReloadCooldown,
+						spaceship.GetReloadCooldown(),
+					),
+					fmt.Sprintf("Spaceship Health:     %d +(%d) -> %d",
+						spaceship.SelectedSpaceship.EntityHealth,
+						spaceship.MaxHealth-spaceship.SelectedSpaceship.EntityHealth,
+						spaceship.MaxHealth,
+					),
+					"---------------------------------",
+					"Talents:",
+				}, spaceship.Talents.GetTalentsSummary()...), func() {
+
+				},
+			),
+			ui.NewUIBox(
+				[]string{
+					"Restart",
+				},
+				[]string{
+					"Return to Main Menu.",
+				},
+				func() {
+					RestartGame(gc, u.cfg, u.exitCha)
+				},
+			),
+			ui.NewUIBox(
+				[]string{
+					"Hangar",
+				},
+				[]string{
+					"Spend credits on new spaceships and permanent upgrades.",
+				}, func() {
+					if s, ok := gc.FindEntity("spaceship").(*SpaceShip); ok {
+						u.HangarMenu(gc, layout, s.LoadedDesigns, func() { u.pauseMenu(gc) })
+					}
+				},
+			),
+			ui.NewUIBox(
+				[]string{
+					"Settings",
+				},
+				[]string{
+					"Change the volume of the sounds, [M] mutes everything.",
+				}, func() {
+					u.SettingsMenu(gc, layout, func() { u.pauseMenu(gc) })
+				},
+			),
+			ui.NewUIBox([]string{
+				"Quit Game",
+			}, []string{"Exit the game."}, func() {
+				base.ExitGame(u.exitCha)
+			}),
 		}
+		menuUi := ui.InitMainMenu(20, 5, boxes...)
+		menuUi.SelectedDesc = []string{"Paused Game"}
+		layout.SetLayout(menuUi)
 	}
 }
@@ -44,18 +44,18 @@ func (u *UICodexMenuBoxesProducer) Update(gc *game.GameContext, delta float64) {
 func (u *UICodexMenuBoxesProducer) InputEvents(events tcell.Event, gc *game.GameContext) {
 	switch ev := events.(type) {
 	case *tcell.EventMouse:
-		mx, my := ev.Position()
+		mx, my := base.MousePosition(ev)
 		for _, b := range u.Boxes {
 			if mx >= b.Position.X && mx < b.Position.X+b.Width && my >= b.Position.Y && my < b.Position.Y+b.Height {
 				if !b.Hovered {
-					gc.Sounds.PlaySound("8-bit-hover-button.mp3", 0)
+					gc.Sounds.PlaySound("ui_hover")
 					b.Hovered = true
 					if len(b.Description) > 0 {
 						u.SelectedDesc = b.Description
 					}
 				}
 				if ev.Buttons() == tcell.Button1 {
-					gc.Sounds.PlaySound("8-bit-game-sfx-sound-select.mp3", 0)
+					gc.Sounds.PlaySound("ui_select")
 					if b.OnClick != nil {
 						b.OnClick()
 					}
@@ -67,14 +67,14 @@ func (u *UICodexMenuBoxesProducer) InputEvents(events tcell.Event, gc *game.Game
 		for _, b := range u.CurrentDisplayList {
 			if mx >= b.Position.X && mx < b.Position.X+b.Width && my >= b.Position.Y && my < b.Position.Y+b.Height {
 				if !b.Hovered {
-					gc.Sounds.PlaySound("8-bit-hover-button.mp3", 0)
+					gc.Sounds.PlaySound("ui_hover")
 					b.Hovered = true
 					if len(b.Description) > 0 {
 						u.SelectedDesc = b.Description
 					}
 				}
 				if ev.Buttons() == tcell.Button1 {
-					gc.Sounds.PlaySound("8-bit-game-sfx-sound-select.mp3", 0)
+					gc.Sounds.PlaySound("ui_select")
 					if b.OnClick != nil {
 						b.OnClick()
 					}
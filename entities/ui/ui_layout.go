@@ -35,16 +35,16 @@ func (u *UILayoutBoxesProducer) Update(gc *game.GameContext, delta float64) {}
 func (u *UILayoutBoxesProducer) InputEvents(events tcell.Event, gc *game.GameContext) {
 	switch ev := events.(type) {
 	case *tcell.EventMouse:
-		mx, my := ev.Position()
+		mx, my := base.MousePosition(ev)
 		for _, b := range u.Boxes {
 			if mx >= b.Position.X && mx < b.Position.X+b.Width && my >= b.Position.Y && my < b.Position.Y+b.Height {
 				if !b.Hovered {
-					gc.Sounds.PlaySound("8-bit-hover-button.mp3", 0)
+					gc.Sounds.PlaySound("ui_hover")
 					b.Hovered = true
 					u.SelectedDesc = b.Description
 				}
 				if ev.Buttons() == tcell.Button1 {
-					gc.Sounds.PlaySound("8-bit-powerup.mp3", 0)
+					gc.Sounds.PlaySound("ui_confirm")
 					b.OnClick()
 				}
 			} else {
@@ -23,6 +23,7 @@ var StartGameDesc = []string{
 	"[LM] hold to shoot a beam to coming alien-ships.",
 	"[E] Consume Health Kit.",
 	"[R] or [RM] Reload Gun.",
+	"[1-5] Switch Weapon, [B] Drop a Bomb to clear enemy fire.",
 	"[P] To Pause The Game.",
 	"[Ctrl+R] To Restart The Game.",
 }
@@ -55,18 +56,18 @@ func (u *UILayoutMenuBoxesProducer) Update(gc *game.GameContext, delta float64)
 func (u *UILayoutMenuBoxesProducer) InputEvents(events tcell.Event, gc *game.GameContext) {
 	switch ev := events.(type) {
 	case *tcell.EventMouse:
-		mx, my := ev.Position()
+		mx, my := base.MousePosition(ev)
 		for _, b := range u.Boxes {
 			if mx >= b.Position.X && mx < b.Position.X+b.Width && my >= b.Position.Y && my < b.Position.Y+b.Height {
 				if !b.Hovered {
-					gc.Sounds.PlaySound("8-bit-hover-button.mp3", 0)
+					gc.Sounds.PlaySound("ui_hover")
 					b.Hovered = true
 					if len(b.Description) > 0 {
 						u.SelectedDesc = b.Description
 					}
 				}
 				if ev.Buttons() == tcell.Button1 {
-					gc.Sounds.PlaySound("8-bit-game-sfx-sound-select.mp3", 0)
+					gc.Sounds.PlaySound("ui_select")
 					b.OnClick()
 				}
 			} else {
@@ -98,6 +98,14 @@ func (ui *UISystem) GetType() string {
 	return "layout"
 }
 
+func (ui *UISystem) GetLayer() game.Layer {
+	return game.LayerMenu
+}
+
+func (ui *UISystem) GetPhase() game.Phase {
+	return game.PhaseInput
+}
+
 func DrawBoxHover(pos base.Point, width, height int, hover bool, fn func(initX, initY int)) {
 	style := base.StyleIt(tcell.ColorWhite)
 	if hover {
@@ -0,0 +1,230 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/omar0ali/spaceinvaders-game-cli/base"
+	"github.com/omar0ali/spaceinvaders-game-cli/game"
+)
+
+type NodeState int
+
+const (
+	NodeLocked NodeState = iota
+	NodeAvailable
+	NodeMaxed
+)
+
+type TreeNode struct {
+	*Box
+	Row, Column   int
+	Rank, MaxRank int
+	State         NodeState
+	Parents       []*TreeNode
+}
+
+func NewTreeNode(name string, row, column int, desc []string, onClick func()) *TreeNode {
+	return &TreeNode{
+		Box:    NewUIBox([]string{name}, desc, onClick),
+		Row:    row,
+		Column: column,
+	}
+}
+
+// UITreeProducer displays nodes as a graph, navigable with the mouse or the arrow keys.
+type UITreeProducer struct {
+	UIProducerBase
+	Nodes    []*TreeNode
+	Selected int
+}
+
+func (u *UITreeProducer) GetTotalBoxes() int {
+	return len(u.Nodes)
+}
+
+func (u *UITreeProducer) GetBoxes() []*Box {
+	boxes := make([]*Box, 0, len(u.Nodes))
+	for _, n := range u.Nodes {
+		boxes = append(boxes, n.Box)
+	}
+	return boxes
+}
+
+func InitTreeLayout(boxWidth, boxHeight int, title []string, nodes ...*TreeNode) *UITreeProducer {
+	u := &UITreeProducer{
+		UIProducerBase: UIProducerBase{
+			Width:        boxWidth,
+			Height:       boxHeight,
+			SelectedDesc: title,
+		},
+		Nodes:    nodes,
+		Selected: -1,
+	}
+	return u
+}
+
+func (u *UITreeProducer) Update(gc *game.GameContext, delta float64) {}
+
+func (u *UITreeProducer) selectNode(i int, gc *game.GameContext) {
+	if i == u.Selected || i < 0 || i >= len(u.Nodes) {
+		return
+	}
+	gc.Sounds.PlaySound("ui_hover")
+	u.Selected = i
+	for j, n := range u.Nodes {
+		n.Hovered = j == i
+	}
+	if len(u.Nodes[i].Description) > 0 {
+		u.SelectedDesc = u.Nodes[i].Description
+	}
+}
+
+func (u *UITreeProducer) click(n *TreeNode, gc *game.GameContext) {
+	if n.State != NodeAvailable || n.OnClick == nil {
+		gc.Sounds.PlaySound("ui_denied")
+		return
+	}
+	gc.Sounds.PlaySound("ui_confirm")
+	n.OnClick()
+}
+
+// closest finds the closest node in the direction (dRow, dCol) from the selected node.
+func (u *UITreeProducer) closest(dRow, dCol int) int {
+	if u.Selected < 0 {
+		return 0
+	}
+	from := u.Nodes[u.Selected]
+	best, bestScore := -1, 0
+	for i, n := range u.Nodes {
+		row, col := n.Row-from.Row, n.Column-from.Column
+		if (dRow != 0 && row*dRow <= 0) || (dCol != 0 && (col*dCol <= 0 || row != 0)) {
+			continue
+		}
+		score := abs(row)*10 + abs(col)
+		if best == -1 || score < bestScore {
+			best, bestScore = i, score
+		}
+	}
+	if best == -1 {
+		return u.Selected
+	}
+	return best
+}
+
+func (u *UITreeProducer) InputEvents(events tcell.Event, gc *game.GameContext) {
+	switch ev := events.(type) {
+	case *tcell.EventKey:
+		switch ev.Key() {
+		case tcell.KeyUp:
+			u.selectNode(u.closest(-1, 0), gc)
+		case tcell.KeyDown:
+			u.selectNode(u.closest(1, 0), gc)
+		case tcell.KeyLeft:
+			u.selectNode(u.closest(0, -1), gc)
+		case tcell.KeyRight:
+			u.selectNode(u.closest(0, 1), gc)
+		case tcell.KeyEnter:
+			if u.Selected >= 0 {
+				u.click(u.Nodes[u.Selected], gc)
+			}
+		}
+	case *tcell.EventMouse:
+		mx, my := base.MousePosition(ev)
+		for i, n := range u.Nodes {
+			if mx >= n.Position.X && mx < n.Position.X+n.Width && my >= n.Position.Y && my < n.Position.Y+n.Height {
+				u.selectNode(i, gc)
+				if ev.Buttons() == tcell.Button1 {
+					u.click(n, gc)
+				}
+			}
+		}
+	}
+}
+
+func (u *UITreeProducer) Draw(gc *game.GameContext) {
+	w, h := base.GetSize()
+
+	const spaceX, spaceY = 4, 2
+	rows, columns := 0, 0
+	for _, n := range u.Nodes {
+		rows = max(rows, n.Row+1)
+		columns = max(columns, n.Column+1)
+	}
+	totalWidth := columns*(u.Width+spaceX) - spaceX
+	totalHeight := rows*(u.Height+spaceY) - spaceY
+	startX := (w / 2) - (totalWidth / 2)
+	startY := max((h/2)-(totalHeight/2)-5, 1)
+
+	for _, n := range u.Nodes {
+		n.Position.X = startX + n.Column*(u.Width+spaceX)
+		n.Position.Y = startY + n.Row*(u.Height+spaceY)
+		n.Width = u.Width
+		n.Height = u.Height
+	}
+
+	// connections first, so the boxes are drawn over them
+	lineStyle := base.StyleIt(tcell.ColorGray)
+	for _, n := range u.Nodes {
+		for _, p := range n.Parents {
+			style := lineStyle
+			if p.Rank > 0 {
+				style = base.StyleIt(tcell.ColorGreenYellow)
+			}
+			fromX, fromY := p.Position.X+p.Width/2, p.Position.Y+p.Height
+			toX, toY := n.Position.X+n.Width/2, n.Position.Y-1
+			for y := fromY; y <= toY; y++ {
+				base.SetContentWithStyle(toX, y, tcell.RuneVLine, style)
+			}
+			for x := min(fromX, toX); x <= max(fromX, toX); x++ {
+				base.SetContentWithStyle(x, fromY, tcell.RuneHLine, style)
+			}
+			base.SetContentWithStyle(toX, toY, '▼', style)
+		}
+	}
+
+	for _, n := range u.Nodes {
+		style := base.StyleIt(tcell.ColorWhite)
+		switch {
+		case n.State == NodeLocked:
+			style = base.StyleIt(tcell.ColorGray)
+		case n.State == NodeMaxed || n.Rank > 0:
+			style = base.StyleIt(tcell.ColorGreenYellow)
+		}
+		if n.Hovered {
+			style = base.StyleIt(tcell.ColorYellow)
+		}
+
+		DrawBox(n.Position, n.Width, n.Height, style)
+		lines := append([]string{}, n.Shape...)
+		if n.MaxRank > 0 {
+			lines = append(lines, fmt.Sprintf("%d/%d", n.Rank, n.MaxRank))
+		}
+		for row, line := range lines {
+			runes := []rune(line)
+			x := n.Position.X + (n.Width / 2) - (len(runes) / 2)
+			for i, r := range runes {
+				base.SetContentWithStyle(x+i, n.Position.Y+1+row, r, style)
+			}
+		}
+	}
+
+	// Description Box
+	style := base.StyleIt(tcell.ColorWhite)
+	width := 60
+	height := len(u.SelectedDesc) + 2
+	DrawBoxOverlap(base.Point{X: (w / 2) - (width / 2), Y: startY + totalHeight + 2}, width, height, func(innerX, innerY int) {
+		for j, line := range u.SelectedDesc {
+			for i, r := range line {
+				base.SetContentWithStyle(innerX+i+2, innerY+j+1, r, style)
+			}
+		}
+	}, style)
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
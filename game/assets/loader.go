@@ -6,5 +6,8 @@ import "embed"
 //go:embed *.json
 var Files embed.FS
 
-//go:embed sounds/*.mp3
+//go:embed sounds/*
 var SoundFS embed.FS
+
+//go:embed music/*.wav
+var MusicFS embed.FS
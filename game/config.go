@@ -2,6 +2,7 @@ package game
 
 import (
 	"log"
+	"slices"
 
 	"github.com/BurntSushi/toml"
 )
@@ -14,6 +15,17 @@ debug = false
 fps_counter = false
 asteroids = true
 sounds = true
+console = false
+profiler = false
+pprof_addr = ""
+trace_file = "trace.out"
+log_path = "debug.log"
+log_format = "text"
+log_level = "debug"
+log_max_size = 1024
+log_max_files = 3
+log_subsystems = []
+slow_frame = 50
 
 [spaceship]
 max_level = 50
@@ -22,8 +34,80 @@ next_level_score = 100
 [stars] 
 limit = 10
 speed = 50
+
+[profile]
+path = "profile.json"
+
+[render]
+low_bandwidth = false
+bandwidth = 32
+
+[screen]
+aspect_ratio = 0
+min_width = 80
+min_height = 24
+
+[effects]
+hit_flash = true
+screen_shake = 1.0
+vignette = true
+freeze_frames = true
+
+[mods]
+path = "mods"
+
+[audio]
+master = 1.0
+muted = false
+sfx = 1.0
+ui = 1.0
+music = 0.7
+alerts = 1.0
+muted_buses = []
 `
 
+// AudioSettings are the volumes from 0 to 1 of the master and of every bus.
+type AudioSettings struct {
+	Master     float64  `toml:"master" json:"master"`
+	Muted      bool     `toml:"muted" json:"muted"` // toggled with M
+	SFX        float64  `toml:"sfx" json:"sfx"`
+	UI         float64  `toml:"ui" json:"ui"`
+	Music      float64  `toml:"music" json:"music"`
+	Alerts     float64  `toml:"alerts" json:"alerts"`
+	MutedBuses []string `toml:"muted_buses" json:"muted_buses"` // sfx, ui, music or alerts
+}
+
+func (a *AudioSettings) Volume(bus Bus) float64 {
+	switch bus {
+	case BusSFX:
+		return a.SFX
+	case BusUI:
+		return a.UI
+	case BusMusic:
+		return a.Music
+	case BusAlerts:
+		return a.Alerts
+	}
+	return 0
+}
+
+func (a *AudioSettings) setVolume(bus Bus, volume float64) {
+	switch bus {
+	case BusSFX:
+		a.SFX = volume
+	case BusUI:
+		a.UI = volume
+	case BusMusic:
+		a.Music = volume
+	case BusAlerts:
+		a.Alerts = volume
+	}
+}
+
+func (a *AudioSettings) IsMuted(bus Bus) bool {
+	return slices.Contains(a.MutedBuses, bus)
+}
+
 type GameConfig struct {
 	SpaceShipConfig struct {
 		MaxLevel       int `toml:"max_level"`
@@ -33,25 +117,65 @@ type GameConfig struct {
 		Limit int `toml:"limit"`
 		Speed int `toml:"speed"`
 	} `toml:"stars"`
-	Dev struct {
+	Profile struct {
+		Path string `toml:"path"`
+	} `toml:"profile"`
+	Render struct {
+		// only the changed cells are sent, low bandwidth mode also skips frames to
+		// stay under the bandwidth (KB/s), i.e over slow SSH links
+		LowBandwidth bool `toml:"low_bandwidth"`
+		Bandwidth    int  `toml:"bandwidth"`
+	} `toml:"render"`
+	Screen struct {
+		// the playfield is letterboxed to the aspect ratio (width/height in cells), it
+		// fills the terminal when 0. Smaller terminals show a warning instead of the game
+		AspectRatio float64 `toml:"aspect_ratio"`
+		MinWidth    int     `toml:"min_width"`
+		MinHeight   int     `toml:"min_height"`
+	} `toml:"screen"`
+	// Effects are the impact feedback, each can be turned off for accessibility
+	Effects struct {
+		HitFlash     bool    `toml:"hit_flash"`     // sprites flash when hit
+		ScreenShake  float64 `toml:"screen_shake"`  // intensity of the shake, 0 turns it off
+		Vignette     bool    `toml:"vignette"`      // red border at low health
+		FreezeFrames bool    `toml:"freeze_frames"` // the action stops for a moment on kills
+	} `toml:"effects"`
+	Mods struct {
+		Path string `toml:"path"` // i.e music/menu.wav in it replaces the embedded menu theme
+	} `toml:"mods"`
+	Audio AudioSettings `toml:"audio"`
+	Dev   struct {
 		Debug      bool `toml:"debug"`
 		FPSCounter bool `toml:"fps_counter"`
 		Asteroids  bool `toml:"asteroids"`
 		Sounds     bool `toml:"sounds"`
+		Console    bool `toml:"console"` // developer console on the backtick key
+		// profiling overlay on F3, runtime trace toggled with F4
+		Profiler  bool   `toml:"profiler"`
+		PprofAddr string `toml:"pprof_addr"` // i.e localhost:6060, disabled when empty
+		TraceFile string `toml:"trace_file"`
+		// logging, only when debug is enabled
+		LogPath       string   `toml:"log_path"`
+		LogFormat     string   `toml:"log_format"`     // text or json
+		LogLevel      string   `toml:"log_level"`      // debug, info, warn or error
+		LogMaxSize    int      `toml:"log_max_size"`   // KB before the log file is rotated, no rotation when 0
+		LogMaxFiles   int      `toml:"log_max_files"`  // rotated files to keep
+		LogSubsystems []string `toml:"log_subsystems"` // game, sound, spawn, collision, ui, frame. All when empty
+		SlowFrame     int      `toml:"slow_frame"`     // ms, frames slower than this log their timing spans
 	} `toml:"dev"`
 }
 
+// LoadConfig reads config.toml over the defaults, so older files without the newer
+// sections (i.e [audio]) keep the default values.
 func LoadConfig() GameConfig {
-	var cfg GameConfig
-	if _, err := toml.DecodeFile("config.toml", &cfg); err == nil {
-		IsDebug = cfg.Dev.Debug
-		return cfg
+	var defaults GameConfig
+	if _, err := toml.Decode(defaultConfig, &defaults); err != nil {
+		log.Fatal("Failed to load configuration or invalid defaultConfig")
 	}
-	if _, err := toml.Decode(defaultConfig, &cfg); err == nil {
-		IsDebug = cfg.Dev.Debug
-		return cfg
+	cfg := defaults
+	if _, err := toml.DecodeFile("config.toml", &cfg); err != nil {
+		cfg = defaults
 	}
-
-	log.Fatal("Failed to load configuration or invalid defaultConfig")
-	return GameConfig{}
+	IsDebug = cfg.Dev.Debug
+	return cfg
 }
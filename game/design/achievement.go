@@ -0,0 +1,22 @@
+package design
+
+// AchievementEvent is the gameplay event that progresses an achievement.
+type AchievementEvent = string
+
+const (
+	EventKill         AchievementEvent = "kill"           // target: name of the enemy
+	EventBossDown     AchievementEvent = "boss_down"      // target: name of the boss
+	EventNoDamageWave AchievementEvent = "no_damage_wave" // a level completed without taking damage
+	EventKitUsed      AchievementEvent = "kit_used"
+	EventLevelReached AchievementEvent = "level_reached" // value: the level, keeps the highest
+	EventShipUsed     AchievementEvent = "ship_used"     // target: name of the spaceship
+)
+
+type AchievementDesign struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Event       AchievementEvent `json:"event"`
+	Target      string           `json:"target"` // only count events of this enemy or spaceship, any when empty
+	Count       int              `json:"count"`  // counter needed to unlock, kept between runs
+}
@@ -2,4 +2,8 @@ package design
 
 type AlienshipDesign struct {
 	SpaceshipDesign
+	// Sound of the gun, a file or a sound from sfx.json
+	Sound string `json:"sound"`
+	// DeathEffect is the particle effect from effects.json when destroyed
+	DeathEffect string `json:"death_effect"`
 }
@@ -12,6 +12,14 @@ type Design struct {
 	EntityHealth int      `json:"health"`
 	Color        string   `json:"color"`
 	Speed        int      `json:"speed"`
+	// ColorMap is parallel to Shape, its characters pick the style of the shape from the
+	// Palette (i.e "e": {"color": "FF4500", "blink": true} for the engines).
+	ColorMap []string                `json:"color_map"`
+	Palette  map[string]PaletteEntry `json:"palette"`
+	// Animations are frame lists by name: idle, death and the ones of the DamageStates
+	Animations   map[string][]Frame `json:"animations"`
+	DamageStates []DamageState      `json:"damage_states"`
+	Defense
 }
 
 type Designable interface {
@@ -20,6 +28,7 @@ type Designable interface {
 	GetName() string
 	GetShape() []string
 	GetMaxSpeed() int
+	StyleAt(row, col int) tcell.Style
 }
 
 func (d *Design) GetColor() tcell.Color { return HexToColor(d.Color) }
@@ -29,13 +38,18 @@ func (d *Design) GetShape() []string    { return d.Shape }
 func (d *Design) GetMaxSpeed() int      { return d.Speed }
 
 type LoadedDesigns struct {
-	HealthKitDesign  Design
-	ModifierDesign   []ModifierDesign
-	ListOfSpaceships []SpaceshipDesign
-	ListOfAbilities  []AbilityDesign
-	ListOfBossShips  []AlienshipDesign
-	ListOfAsteroids  AsteroidDesign
-	ListOfAlienships []AlienshipDesign
+	HealthKitDesign    Design
+	ModifierDesign     []ModifierDesign
+	ListOfSpaceships   []SpaceshipDesign
+	ListOfAbilities    []AbilityDesign
+	ListOfBossShips    []AlienshipDesign
+	ListOfAsteroids    AsteroidDesign
+	ListOfAlienships   []AlienshipDesign
+	ListOfWeapons      []WeaponDesign
+	ListOfTalents      []TalentTreeDesign
+	ListOfUpgrades     []UpgradeDesign
+	ListOfAchievements []AchievementDesign
+	ListOfEffects      []EffectDesign
 }
 
 func LoadDesigns() *LoadedDesigns {
@@ -68,13 +82,43 @@ func LoadDesigns() *LoadedDesigns {
 		panic(err)
 	}
 
+	listOfWeapons, err := loader.LoadListOfAssets[WeaponDesign]("weapons.json")
+	if err != nil {
+		panic(err)
+	}
+
+	listOfTalents, err := loader.LoadListOfAssets[TalentTreeDesign]("talents.json")
+	if err != nil {
+		panic(err)
+	}
+
+	listOfUpgrades, err := loader.LoadListOfAssets[UpgradeDesign]("armory.json")
+	if err != nil {
+		panic(err)
+	}
+
+	listOfAchievements, err := loader.LoadListOfAssets[AchievementDesign]("achievements.json")
+	if err != nil {
+		panic(err)
+	}
+
+	listOfEffects, err := loader.LoadListOfAssets[EffectDesign]("effects.json")
+	if err != nil {
+		panic(err)
+	}
+
 	return &LoadedDesigns{
-		HealthKitDesign:  healthKitDesign,
-		ModifierDesign:   modifierDesigns,
-		ListOfSpaceships: listOfSpaceships,
-		ListOfAbilities:  listOfAbilities,
-		ListOfBossShips:  listOfBossShips,
-		ListOfAsteroids:  listOfAsteroids,
-		ListOfAlienships: listOfAlienships,
+		HealthKitDesign:    healthKitDesign,
+		ModifierDesign:     modifierDesigns,
+		ListOfSpaceships:   listOfSpaceships,
+		ListOfAbilities:    listOfAbilities,
+		ListOfBossShips:    listOfBossShips,
+		ListOfAsteroids:    listOfAsteroids,
+		ListOfAlienships:   listOfAlienships,
+		ListOfWeapons:      listOfWeapons,
+		ListOfTalents:      listOfTalents,
+		ListOfUpgrades:     listOfUpgrades,
+		ListOfAchievements: listOfAchievements,
+		ListOfEffects:      listOfEffects,
 	}
 }
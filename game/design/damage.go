@@ -0,0 +1,19 @@
+package design
+
+type DamageType = string
+
+const (
+	Kinetic   DamageType = "kinetic"
+	Energy    DamageType = "energy"
+	Explosive DamageType = "explosive"
+	Collision DamageType = "collision"
+)
+
+// Defense describes how an entity mitigates the damage it takes.
+type Defense struct {
+	Armor            int                    `json:"armor"`              // flat reduction on every hit that reaches the hull
+	Resistances      map[DamageType]float64 `json:"resistances"`        // 0.5 halves the damage, negative values are weaknesses
+	Shield           int                    `json:"shield"`             // absorbs damage before health
+	ShieldRegen      float64                `json:"shield_regen"`       // shield points per second
+	ShieldRegenDelay int                    `json:"shield_regen_delay"` // ms after the last hit before regenerating
+}
@@ -0,0 +1,58 @@
+package design
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// EffectDesign is a particle emitter listed in effects.json, designs refer to it by name
+// (i.e death_effect on an alien ship or impact_effect on a weapon).
+type EffectDesign struct {
+	Name     string `json:"name"`
+	Count    int    `json:"count"`    // particles spawned at once
+	Rate     int    `json:"rate"`     // particles per second while the emitter runs
+	Duration int    `json:"duration"` // ms the emitter keeps spawning at the rate
+	Area     bool   `json:"area"`     // spawn anywhere on the object instead of its centre
+	Collides bool   `json:"collides"` // the particles crash into the ships (i.e debris)
+	// velocity in cells per second, the angle is in degrees (0 is right, 90 is up) and
+	// particles leave within spread degrees around it, 360 is every direction
+	Speed       float64 `json:"speed"`
+	SpeedJitter float64 `json:"speed_jitter"` // up to this much faster, picked per particle
+	Angle       float64 `json:"angle"`
+	Spread      float64 `json:"spread"`
+	Gravity     float64 `json:"gravity"`         // cells per second squared, pulling down
+	Drag        float64 `json:"drag"`            // fraction of the velocity lost per second
+	Lifetime    int     `json:"lifetime"`        // ms
+	LifeJitter  int     `json:"lifetime_jitter"` // up to this many ms longer
+	// Symbols and Colors (hex) are spread over the life of a particle, the colours
+	// blend into each other (i.e yellow to red to grey for a fire)
+	Symbols string   `json:"symbols"`
+	Colors  []string `json:"colors"`
+}
+
+// ColorAt is the colour of a particle at t, from 0 (spawned) to 1 (end of its life).
+func (e *EffectDesign) ColorAt(t float64) tcell.Color {
+	switch len(e.Colors) {
+	case 0:
+		return tcell.ColorWhite
+	case 1:
+		return HexToColor(e.Colors[0])
+	}
+	t = min(max(t, 0), 1) * float64(len(e.Colors)-1)
+	i := min(int(t), len(e.Colors)-2)
+	r1, g1, b1 := HexToColor(e.Colors[i]).RGB()
+	r2, g2, b2 := HexToColor(e.Colors[i+1]).RGB()
+	f := t - float64(i)
+	blend := func(a, b int32) int32 {
+		return a + int32(float64(b-a)*f)
+	}
+	return tcell.NewRGBColor(blend(r1, r2), blend(g1, g2), blend(b1, b2))
+}
+
+// SymbolAt is the symbol of a particle at t, from 0 (spawned) to 1 (end of its life).
+func (e *EffectDesign) SymbolAt(t float64) rune {
+	symbols := []rune(e.Symbols)
+	if len(symbols) == 0 {
+		return '*'
+	}
+	return symbols[min(int(max(t, 0)*float64(len(symbols))), len(symbols)-1)]
+}
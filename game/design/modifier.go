@@ -1,5 +1,22 @@
 package design
 
+type ModifierEffect = string
+
+const (
+	Invulnerable ModifierEffect = "invulnerable"
+	DoubleScore  ModifierEffect = "double_score"
+	SlowMotion   ModifierEffect = "slow_motion"
+)
+
+// Stacking decides what happens when a timed modifier is picked while still active.
+type Stacking = string
+
+const (
+	Refresh Stacking = "refresh" // restart the countdown (default)
+	Extend  Stacking = "extend"  // add the duration to the time left
+	Stack   Stacking = "stack"   // apply the changes again up to max_stacks
+)
+
 type ModifierDesign struct {
 	Design
 	ModifyHealth            int  `json:"modify_health"`
@@ -10,4 +27,11 @@ type ModifierDesign struct {
 	ModifyGunCoolDown       int  `json:"modify_gun_cooldown"`
 	ModifyGunReloadCoolDown int  `json:"modify_gun_reload_cooldown"`
 	MaxValue                int  `json:"max_value"`
+	// Weapon the modifier applies to by name, the primary gun when empty.
+	Weapon string `json:"weapon"`
+	// Timed modifiers revert their changes once the duration (ms) runs out, permanent when 0.
+	Duration  int            `json:"duration"`
+	Effect    ModifierEffect `json:"effect"`
+	Stacking  Stacking       `json:"stacking"`
+	MaxStacks int            `json:"max_stacks"`
 }
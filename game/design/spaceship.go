@@ -8,6 +8,8 @@ type AbilityEffect struct {
 	ReloadCooldownDecrease int `json:"reload_cooldown_decrease"`
 	HealthCpacity          int `json:"health_capacity_increase"`
 	MaxValue               int `json:"max_value"`
+	// Weapon the ability applies to by name, the primary gun when empty.
+	Weapon string `json:"weapon"`
 }
 
 type AbilityDesign struct {
@@ -25,4 +27,8 @@ type SpaceshipDesign struct {
 	GunCap            int `json:"gun_cap"`
 	GunCooldown       int `json:"gun_cooldown"`
 	GunReloadCooldown int `json:"gun_reload_cooldown"`
+	// DamageType of the beams, kinetic when empty.
+	DamageType DamageType `json:"damage_type"`
+	// UnlockCost in credits, unlocked from the start when zero.
+	UnlockCost int `json:"unlock_cost"`
 }
@@ -0,0 +1,82 @@
+package design
+
+import "github.com/gdamore/tcell/v2"
+
+// PaletteEntry is the style of the characters mapped to it in the color map of a design.
+type PaletteEntry struct {
+	Color      string `json:"color"`      // hex, the color of the design when empty
+	Background string `json:"background"` // hex, transparent when empty
+	Bold       bool   `json:"bold"`
+	Blink      bool   `json:"blink"`
+}
+
+// StyleAt is the style of the character of the shape at row, col. The color map is
+// parallel to the shape, its characters are keys of the palette. Characters without a
+// palette entry are drawn in the color of the design.
+func (d *Design) StyleAt(row, col int) tcell.Style {
+	style := tcell.StyleDefault.Background(tcell.ColorReset).Foreground(d.GetColor())
+	if row >= len(d.ColorMap) {
+		return style
+	}
+	line := []rune(d.ColorMap[row])
+	if col >= len(line) {
+		return style
+	}
+	entry, ok := d.Palette[string(line[col])]
+	if !ok {
+		return style
+	}
+	if entry.Color != "" {
+		style = style.Foreground(HexToColor(entry.Color))
+	}
+	if entry.Background != "" {
+		style = style.Background(HexToColor(entry.Background))
+	}
+	return style.Bold(entry.Bold).Blink(entry.Blink)
+}
+
+const (
+	AnimationIdle  = "idle"  // loops while no damage state is reached
+	AnimationDeath = "death" // played once before the explosion
+)
+
+// Frame of an animation, drawn instead of the shape for its duration. Frames keep the
+// size of the shape.
+type Frame struct {
+	Shape    []string `json:"shape"`
+	ColorMap []string `json:"color_map"` // the color map of the design when empty
+	Duration int      `json:"duration"`  // ms
+}
+
+// DamageState swaps the idle animation once the health drops to the fraction, i.e
+// {"health": 0.5, "animation": "cracked"} at half health.
+type DamageState struct {
+	Health    float64 `json:"health"`
+	Animation string  `json:"animation"`
+}
+
+// AnimationFor is the animation of the lowest damage state reached by the health left,
+// or the idle animation.
+func (d *Design) AnimationFor(health, maxHealth int) string {
+	name, lowest := AnimationIdle, 1.0
+	if maxHealth <= 0 {
+		return name
+	}
+	left := float64(health) / float64(maxHealth)
+	for _, state := range d.DamageStates {
+		if left <= state.Health && state.Health <= lowest {
+			name, lowest = state.Animation, state.Health
+		}
+	}
+	return name
+}
+
+// WithFrame is the design drawn with the shape of the frame.
+func (d *Design) WithFrame(f Frame) *Design {
+	frame := *d
+	frame.Shape = f.Shape
+	if len(f.ColorMap) > 0 {
+		frame.ColorMap = f.ColorMap
+	}
+	return &frame
+}
@@ -0,0 +1,17 @@
+package design
+
+type TalentDesign struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Ability  string   `json:"ability"`  // name of the ability (abilities.json) applied on each rank
+	MaxRank  int      `json:"max_rank"` // how many times the talent can be picked
+	Tier     int      `json:"tier"`     // row in the tree
+	Column   int      `json:"column"`   // position in the row
+	Requires []string `json:"requires"` // ids of the talents that need at least one rank
+	Excludes []string `json:"excludes"` // ids of the talents that can't be learned together (branches)
+}
+
+type TalentTreeDesign struct {
+	Ship    string         `json:"ship"` // name of the spaceship, the default tree when empty
+	Talents []TalentDesign `json:"talents"`
+}
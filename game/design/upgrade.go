@@ -0,0 +1,17 @@
+package design
+
+// UpgradeDesign is a permanent upgrade bought in the armory, applied at the start of every run.
+type UpgradeDesign struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Cost        int           `json:"cost"`     // cost of the first rank, each rank costs more
+	MaxRank     int           `json:"max_rank"` // how many times the upgrade can be bought
+	Effect      AbilityEffect `json:"effect"`
+	HealthKits  int           `json:"health_kits"` // health kits owned at the start
+}
+
+// GetCost returns the cost of the next rank.
+func (u *UpgradeDesign) GetCost(rank int) int {
+	return u.Cost * (rank + 1)
+}
@@ -0,0 +1,31 @@
+package design
+
+type WeaponKind = string
+
+const (
+	Spread   WeaponKind = "spread"
+	Piercing WeaponKind = "piercing"
+	Homing   WeaponKind = "homing"
+	Charge   WeaponKind = "charge"
+	Bomb     WeaponKind = "bomb"
+)
+
+type WeaponDesign struct {
+	SpaceshipDesign
+	Kind             WeaponKind `json:"kind"`
+	Description      string     `json:"description"`
+	Symbol           string     `json:"symbol"`
+	Sound            string     `json:"sound"`
+	ImpactEffect     string     `json:"impact_effect"`     // particle effect from effects.json
+	Spread           int        `json:"spread"`            // number of beams per shot
+	Pierce           int        `json:"pierce"`            // extra targets a beam goes through
+	ChargeTime       int        `json:"charge_time"`       // ms to reach a full charge
+	ChargeMultiplier int        `json:"charge_multiplier"` // gun power multiplier at full charge
+}
+
+func (w *WeaponDesign) GetSymbol() rune {
+	for _, r := range w.Symbol {
+		return r
+	}
+	return '↑'
+}
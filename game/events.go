@@ -0,0 +1,97 @@
+package game
+
+import (
+	"reflect"
+	"sync"
+)
+
+// events published on the bus
+type (
+	EnemyKilled struct {
+		Name   string
+		Health int // max health of the enemy, used for the score
+		Boss   bool
+	}
+	PlayerDamaged struct {
+		Source string // name of the entity, shown on the game over screen
+		Power  int
+		Hit    string // recorded in the damage report when not empty
+	}
+	PickupCollected struct {
+		Name string
+	}
+	LevelUp struct {
+		Level int
+	}
+	BossSpawned struct {
+		Name string
+	}
+	UpgradeChosen struct {
+		Name string
+	}
+	GameOver struct {
+		Score int
+		Kills int
+		Level int
+	}
+	// Resized is published when the playfield changes size, objects keep their relative position.
+	Resized struct {
+		Width, Height         int
+		PrevWidth, PrevHeight int
+	}
+)
+
+// EventBus delivers the published events in order, at the end of the frame they were
+// published in. Events published by a handler are delivered in the same frame.
+type EventBus struct {
+	mu       sync.Mutex
+	handlers map[reflect.Type][]func(any)
+	queue    []any
+}
+
+func Subscribe[E any](gc *GameContext, fn func(E)) {
+	b := &gc.Events
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.handlers == nil {
+		b.handlers = map[reflect.Type][]func(any){}
+	}
+	t := reflect.TypeFor[E]()
+	b.handlers[t] = append(b.handlers[t], func(e any) {
+		fn(e.(E))
+	})
+}
+
+func Publish[E any](gc *GameContext, event E) {
+	b := &gc.Events
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.queue = append(b.queue, event)
+}
+
+// Flush delivers the queued events, called once per frame by the game loop.
+func (b *EventBus) Flush() {
+	for {
+		b.mu.Lock()
+		if len(b.queue) == 0 {
+			b.mu.Unlock()
+			return
+		}
+		event := b.queue[0]
+		b.queue = b.queue[1:]
+		handlers := b.handlers[reflect.TypeOf(event)]
+		b.mu.Unlock()
+
+		for _, fn := range handlers {
+			fn(event)
+		}
+	}
+}
+
+// Reset drops all subscribers and pending events, used when the game restarts.
+func (b *EventBus) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = nil
+	b.queue = nil
+}
@@ -2,36 +2,70 @@
 package game
 
 import (
-	"log"
-	"os"
+	"slices"
 
 	"github.com/gdamore/tcell/v2"
 )
 
-type LogType string
+// Layer is the z-order of what is drawn, higher layers cover the lower ones. Entities
+// on the same layer are drawn in the order they were added.
+type Layer int
 
 const (
-	Info  LogType = "INFO"
-	Warn  LogType = "WARN"
-	Error LogType = "ERROR"
-	Debug LogType = "DEBUG"
+	LayerBackground Layer = iota // stars
+	LayerActors                  // spaceships, asteroids, modifiers
+	LayerEffects                 // particles
+	LayerHUD
+	LayerMenu
+	LayerCount
 )
 
+// Phase is when an entity is updated within a frame, in this order.
+type Phase int
+
+const (
+	PhaseInput    Phase = iota // menus and overlays reacting to the input
+	PhaseSimulate              // movement of the spaceship and the particles
+	PhaseCollide               // enemies and pickups checking their collisions
+	PhaseRender                // cosmetic updates, i.e the background
+)
+
+// RunsHalted reports whether the phase keeps running while the game is halted (menus,
+// pause), the simulation and collisions are frozen.
+func (p Phase) RunsHalted() bool {
+	return p == PhaseInput || p == PhaseRender
+}
+
 type (
 	Entity interface {
 		Draw(gc *GameContext)
 		Update(gc *GameContext, delta float64)
 		InputEvents(event tcell.Event, gc *GameContext)
 		GetType() string
+		GetLayer() Layer
+		GetPhase() Phase
 	}
 	GameContext struct {
 		entities []Entity
 		Screen   tcell.Screen
-		Halt     bool
 		Sounds   *SoundSystem
+		Profile  *Profile
+		Events   EventBus
+		Scenes   SceneStack // game flow, the top scene halts the game and gets the input first
+		// TimeScale multiplies the delta of every frame (dev console), 1 is normal speed.
+		TimeScale float64
+		// FreezeTime is how long (seconds) the simulation stays frozen, i.e a freeze-frame
+		// on kills. Counted down by the game loop.
+		FreezeTime float64
+		Frame      *FrameSpans // timing of the last frames, read by the profiler
 	}
 )
 
+// Freeze stops the simulation for the duration (seconds), a longer freeze isn't cut short.
+func (gc *GameContext) Freeze(seconds float64) {
+	gc.FreezeTime = max(gc.FreezeTime, seconds)
+}
+
 func (gc *GameContext) AddEntity(entity ...Entity) {
 	gc.entities = append(gc.entities, entity...)
 }
@@ -62,21 +96,20 @@ func (gc *GameContext) FindEntity(typeName string) Entity {
 	return nil
 }
 
-func Log(logType LogType, format string, v ...any) {
-	if !IsDebug {
-		return
-	}
-	log.Printf(string("["+logType+"] ")+format, v...)
+// EntitiesByPhase returns the entities in update order.
+func (gc *GameContext) EntitiesByPhase() []Entity {
+	entities := slices.Clone(gc.entities)
+	slices.SortStableFunc(entities, func(a, b Entity) int {
+		return int(a.GetPhase()) - int(b.GetPhase())
+	})
+	return entities
 }
 
-func SetupLogs() *os.File {
-	f, err := os.Create("debug.log")
-	if err != nil {
-		panic(err)
-	}
-
-	log.SetOutput(f)
-	log.SetFlags(log.LstdFlags | log.Lshortfile) // optional: timestamp + file info
-	log.Println("Starting game...")
-	return f
+// EntitiesByLayer returns the entities in draw order, the bottom layer first.
+func (gc *GameContext) EntitiesByLayer() []Entity {
+	entities := slices.Clone(gc.entities)
+	slices.SortStableFunc(entities, func(a, b Entity) int {
+		return int(a.GetLayer()) - int(b.GetLayer())
+	})
+	return entities
 }
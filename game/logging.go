@@ -0,0 +1,230 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Subsystem = string
+
+const (
+	SubsystemGame      Subsystem = "game"
+	SubsystemSound     Subsystem = "sound"
+	SubsystemSpawn     Subsystem = "spawn"
+	SubsystemCollision Subsystem = "collision"
+	SubsystemUI        Subsystem = "ui"
+	SubsystemFrame     Subsystem = "frame" // timing spans of slow frames
+)
+
+// logs are discarded until SetupLogs is called
+var logger = slog.New(slog.DiscardHandler)
+
+// Logger returns the logger of the subsystem, filtered by log_subsystems in the config.
+func Logger(subsystem Subsystem) *slog.Logger {
+	return logger.With("subsystem", subsystem)
+}
+
+// SetupLogs creates the log file from the [dev] config, the returned closer flushes the file.
+func SetupLogs(cfg GameConfig) io.Closer {
+	path := cfg.Dev.LogPath
+	if path == "" {
+		path = "debug.log"
+	}
+	file, err := newRotatingFile(path, int64(cfg.Dev.LogMaxSize)*1024, cfg.Dev.LogMaxFiles)
+	if err != nil {
+		panic(err)
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Dev.LogLevel)); err != nil {
+		level = slog.LevelDebug
+	}
+	opts := &slog.HandlerOptions{Level: level, AddSource: true}
+
+	var handler slog.Handler = slog.NewTextHandler(file, opts)
+	if strings.EqualFold(cfg.Dev.LogFormat, "json") {
+		handler = slog.NewJSONHandler(file, opts)
+	}
+	logger = slog.New(&subsystemFilter{
+		Handler:    handler,
+		subsystems: cfg.Dev.LogSubsystems,
+	})
+
+	// the std log package (i.e log.Fatal in base) goes through the same handler
+	slog.SetDefault(logger)
+	log.SetFlags(0)
+
+	Logger(SubsystemGame).Info("starting game")
+	return file
+}
+
+// subsystemFilter drops the records of the subsystems that are not enabled, all are
+// enabled when the list is empty.
+type subsystemFilter struct {
+	slog.Handler
+	subsystems []string
+	subsystem  string
+}
+
+func (h *subsystemFilter) Enabled(ctx context.Context, level slog.Level) bool {
+	if len(h.subsystems) > 0 && h.subsystem != "" && !slices.Contains(h.subsystems, h.subsystem) {
+		return false
+	}
+	return h.Handler.Enabled(ctx, level)
+}
+
+func (h *subsystemFilter) WithAttrs(attrs []slog.Attr) slog.Handler {
+	subsystem := h.subsystem
+	for _, a := range attrs {
+		if a.Key == "subsystem" {
+			subsystem = a.Value.String()
+		}
+	}
+	return &subsystemFilter{
+		Handler:    h.Handler.WithAttrs(attrs),
+		subsystems: h.subsystems,
+		subsystem:  subsystem,
+	}
+}
+
+func (h *subsystemFilter) WithGroup(name string) slog.Handler {
+	return &subsystemFilter{
+		Handler:    h.Handler.WithGroup(name),
+		subsystems: h.subsystems,
+		subsystem:  h.subsystem,
+	}
+}
+
+// rotatingFile starts a new file when maxSize is reached, keeping maxFiles old files
+// named path.1 (newest) to path.N (oldest).
+type rotatingFile struct {
+	mu       sync.Mutex
+	path     string
+	maxSize  int64 // bytes, no rotation when zero
+	maxFiles int
+	file     *os.File
+	size     int64
+}
+
+func newRotatingFile(path string, maxSize int64, maxFiles int) (*rotatingFile, error) {
+	r := &rotatingFile{path: path, maxSize: maxSize, maxFiles: max(maxFiles, 1)}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.Create(r.path)
+	if err != nil {
+		return err
+	}
+	r.file, r.size = f, 0
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	for i := r.maxFiles - 1; i > 0; i-- {
+		_ = os.Rename(fmt.Sprintf("%s.%d", r.path, i), fmt.Sprintf("%s.%d", r.path, i+1))
+	}
+	if err := os.Rename(r.path, r.path+".1"); err != nil {
+		return err
+	}
+	return r.open()
+}
+
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+// frameHistory is how many frame times are kept for the profiler graph.
+const frameHistory = 120
+
+type Span struct {
+	Name     string // entity type and phase, i.e alien.update
+	Duration time.Duration
+}
+
+// FrameSpans times the parts of a frame, the spans are logged only when the whole
+// frame took longer than the threshold. The profiler overlay reads the last frame.
+type FrameSpans struct {
+	threshold time.Duration
+	Profiling bool // record the spans even when the slow frame log is disabled, set by the profiler
+	start     time.Time
+	spans     []Span
+
+	LastSpans []Span          // spans of the last complete frame
+	History   []time.Duration // frame times, oldest first
+}
+
+func NewFrameSpans(cfg GameConfig) *FrameSpans {
+	return &FrameSpans{threshold: time.Duration(cfg.Dev.SlowFrame) * time.Millisecond}
+}
+
+func (f *FrameSpans) recording() bool {
+	return f.threshold > 0 || f.Profiling
+}
+
+func (f *FrameSpans) Begin() {
+	f.start = time.Now()
+	f.spans = f.spans[:0]
+}
+
+func (f *FrameSpans) Span(name string, fn func()) {
+	if !f.recording() {
+		fn()
+		return
+	}
+	start := time.Now()
+	fn()
+	f.spans = append(f.spans, Span{Name: name, Duration: time.Since(start)})
+}
+
+func (f *FrameSpans) End() {
+	if !f.recording() {
+		return
+	}
+	elapsed := time.Since(f.start)
+	f.LastSpans = append(f.LastSpans[:0], f.spans...)
+	f.History = append(f.History, elapsed)
+	if len(f.History) > frameHistory {
+		f.History = f.History[len(f.History)-frameHistory:]
+	}
+
+	if f.threshold > 0 && elapsed > f.threshold {
+		attrs := make([]any, len(f.spans))
+		for i, s := range f.spans {
+			attrs[i] = slog.Duration(s.Name, s.Duration)
+		}
+		Logger(SubsystemFrame).LogAttrs(context.Background(), slog.LevelWarn, "slow frame",
+			slog.Duration("elapsed", elapsed),
+			slog.Group("spans", attrs...),
+		)
+	}
+}
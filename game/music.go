@@ -0,0 +1,211 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gopxl/beep/v2"
+	"github.com/gopxl/beep/v2/speaker"
+	"github.com/omar0ali/spaceinvaders-game-cli/game/assets"
+)
+
+// Theme is a looping music track, the player crossfades from one to the other.
+type Theme = string
+
+const (
+	ThemeNone     Theme = "" // fades the music out
+	ThemeMenu     Theme = "menu"
+	ThemeGameplay Theme = "gameplay"
+	ThemeBoss     Theme = "boss"
+)
+
+const musicFade = 2 * time.Second
+
+// musicLayer is played over its theme once there are enough enemies on screen. Layers
+// have the length of the theme so they loop in time with it.
+type musicLayer struct {
+	file    string
+	enemies int
+}
+
+type musicTheme struct {
+	file   string
+	layers []musicLayer
+}
+
+// musicThemes are read from the embedded music, a file with the same name in the music
+// directory of the mods replaces it.
+var musicThemes = map[Theme]musicTheme{
+	ThemeMenu: {file: "menu.wav"},
+	ThemeGameplay: {file: "gameplay.wav", layers: []musicLayer{
+		{file: "gameplay_drums.wav", enemies: 3},
+		{file: "gameplay_lead.wav", enemies: 6},
+	}},
+	ThemeBoss: {file: "boss.wav"},
+}
+
+// loadMusic decodes the files of every theme, the ones that fail are logged and skipped.
+func loadMusic(modsPath string) map[string]*beep.Buffer {
+	music := map[string]*beep.Buffer{}
+	load := func(name string) {
+		if _, ok := music[name]; ok {
+			return
+		}
+		data, err := os.ReadFile(filepath.Join(modsPath, "music", name))
+		if err == nil {
+			Logger(SubsystemSound).Info("music from mods", "name", name)
+		} else {
+			data, err = assets.MusicFS.ReadFile("music/" + name)
+		}
+		if err != nil {
+			Logger(SubsystemSound).Error("failed to read music", "name", name, "err", err)
+			return
+		}
+		buffer, err := decodeSound(name, data)
+		if err != nil || buffer.Len() == 0 {
+			Logger(SubsystemSound).Error("failed to decode music", "name", name, "err", err)
+			return
+		}
+		music[name] = buffer
+	}
+	for _, theme := range musicThemes {
+		load(theme.file)
+		for _, layer := range theme.layers {
+			load(layer.file)
+		}
+	}
+	return music
+}
+
+// fader moves the gain toward the target over musicFade.
+type fader struct {
+	gain, target float64
+}
+
+func (f *fader) next() float64 {
+	step := 1 / float64(sampleRate.N(musicFade))
+	if f.gain < f.target {
+		f.gain = min(f.gain+step, f.target)
+	} else if f.gain > f.target {
+		f.gain = max(f.gain-step, f.target)
+	}
+	return f.gain
+}
+
+type musicPart struct {
+	fader
+	streamer beep.StreamSeeker
+	enemies  int
+}
+
+// musicVoice loops a theme and its layers, muted layers keep streaming to stay in time.
+// It is dropped from the mixer once faded out.
+type musicVoice struct {
+	fader
+	theme Theme
+	parts []*musicPart
+	buf   [][2]float64
+}
+
+func (m *musicVoice) Stream(samples [][2]float64) (int, bool) {
+	if m.gain == 0 && m.target == 0 {
+		return 0, false
+	}
+	if len(m.buf) < len(samples) {
+		m.buf = make([][2]float64, len(samples))
+	}
+	clear(samples)
+	for _, p := range m.parts {
+		buf := m.buf[:len(samples)]
+		loop(p.streamer, buf)
+		for i := range samples {
+			g := p.next()
+			samples[i][0] += buf[i][0] * g
+			samples[i][1] += buf[i][1] * g
+		}
+	}
+	for i := range samples {
+		g := m.next()
+		samples[i][0] *= g
+		samples[i][1] *= g
+	}
+	return len(samples), true
+}
+
+func (m *musicVoice) Err() error {
+	return nil
+}
+
+// loop fills the samples, seeking back to the start at the end of the track.
+func loop(s beep.StreamSeeker, samples [][2]float64) {
+	for n := 0; n < len(samples); {
+		k, _ := s.Stream(samples[n:])
+		n += k
+		if n < len(samples) && s.Seek(0) != nil {
+			clear(samples[n:])
+			return
+		}
+	}
+}
+
+// PlayMusic crossfades to the theme, playing the same theme again does nothing.
+func (s *SoundSystem) PlayMusic(theme Theme) {
+	if s.master == nil {
+		return
+	}
+	speaker.Lock()
+	defer speaker.Unlock()
+	if s.music != nil {
+		if s.music.theme == theme {
+			return
+		}
+		s.music.target = 0 // the mixer drops it once faded out
+		s.music = nil
+	}
+
+	t, ok := musicThemes[theme]
+	if !ok || s.musicBuffers[t.file] == nil {
+		return
+	}
+	v := &musicVoice{theme: theme, fader: fader{target: 1}}
+	v.parts = append(v.parts, &musicPart{
+		streamer: s.musicBuffers[t.file].Streamer(0, s.musicBuffers[t.file].Len()),
+		fader:    fader{gain: 1, target: 1},
+	})
+	for _, layer := range t.layers {
+		if buffer := s.musicBuffers[layer.file]; buffer != nil {
+			v.parts = append(v.parts, &musicPart{
+				streamer: buffer.Streamer(0, buffer.Len()),
+				enemies:  layer.enemies,
+			})
+		}
+	}
+	s.music = v
+	s.setIntensity()
+	s.buses[BusMusic].mixer.Add(v)
+	Logger(SubsystemSound).Debug("music playing", "theme", theme)
+}
+
+// SetIntensity fades the layers of the theme in or out from the number of enemies on screen.
+func (s *SoundSystem) SetIntensity(enemies int) {
+	if s.master == nil {
+		return
+	}
+	speaker.Lock()
+	defer speaker.Unlock()
+	s.intensity = enemies
+	s.setIntensity()
+}
+
+func (s *SoundSystem) setIntensity() {
+	if s.music == nil {
+		return
+	}
+	for _, p := range s.music.parts {
+		p.target = 0
+		if s.intensity >= p.enemies {
+			p.target = 1
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
+)
+
+const defaultProfilePath = "profile.json"
+
+// migrations upgrade a stored profile one version at a time, the index is the version
+// the migration starts from. Never edit a released migration, append a new one instead.
+var migrations = []func(p map[string]any){
+	// 0 -> 1: profiles before versioning had no upgrades or run counter
+	func(p map[string]any) {
+		if _, ok := p["upgrades"]; !ok {
+			p["upgrades"] = map[string]any{}
+		}
+		if _, ok := p["runs"]; !ok {
+			p["runs"] = 0
+		}
+	},
+	// 1 -> 2: achievements and their counters
+	func(p map[string]any) {
+		p["achievements"] = map[string]any{}
+		p["counters"] = map[string]any{}
+	},
+	// 2 -> 3: audio settings, older profiles keep the [audio] config until they change
+	func(p map[string]any) {},
+}
+
+// ProfileVersion is the version written to the profile file.
+var ProfileVersion = len(migrations)
+
+// Profile is kept between runs: credits, unlocked spaceships, permanent upgrades and achievements.
+type Profile struct {
+	Version       int             `json:"version"`
+	Credits       int             `json:"credits"`
+	UnlockedShips []string        `json:"unlocked_ships"`
+	Upgrades      map[string]int  `json:"upgrades"`
+	Runs          int             `json:"runs"`
+	Achievements  map[string]bool `json:"achievements"`    // unlocked achievements by id
+	Counters      map[string]int  `json:"counters"`        // progress of the achievements by id
+	Audio         *AudioSettings  `json:"audio,omitempty"` // from the settings menu, nil is the [audio] config
+
+	path     string
+	readOnly bool // written by a newer version of the game, saving would lose data
+}
+
+func newProfile(path string) *Profile {
+	return &Profile{
+		Version:      ProfileVersion,
+		Upgrades:     map[string]int{},
+		Achievements: map[string]bool{},
+		Counters:     map[string]int{},
+		path:         path,
+	}
+}
+
+func LoadProfile(cfg GameConfig) *Profile {
+	path := cfg.Profile.Path
+	if path == "" {
+		path = defaultProfilePath
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		Logger(SubsystemGame).Info("no profile found, creating", "path", path)
+		return newProfile(path)
+	}
+	if err != nil {
+		Logger(SubsystemGame).Error("failed to read profile", "path", path, "err", err)
+		return newProfile(path)
+	}
+
+	profile, err := decodeProfile(data)
+	if err != nil {
+		// keep the broken file around instead of overwriting it
+		Logger(SubsystemGame).Error("invalid profile, backing up", "path", path, "backup", path+".bak", "err", err)
+		_ = os.WriteFile(path+".bak", data, 0o644)
+		return newProfile(path)
+	}
+	profile.path = path
+	if profile.Version > ProfileVersion {
+		Logger(SubsystemGame).Warn("profile is newer than the game, progress will not be saved",
+			"version", profile.Version, "supported", ProfileVersion)
+		profile.readOnly = true
+	}
+	return profile
+}
+
+func decodeProfile(data []byte) (*Profile, error) {
+	raw := map[string]any{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	version := 0
+	if v, ok := raw["version"].(float64); ok {
+		version = int(v)
+	}
+	for ; version < ProfileVersion; version++ {
+		Logger(SubsystemGame).Info("migrating profile", "from", version, "to", version+1)
+		migrations[version](raw)
+		raw["version"] = version + 1
+	}
+
+	migrated, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	profile := newProfile("")
+	if err := json.Unmarshal(migrated, profile); err != nil {
+		return nil, err
+	}
+	if profile.Upgrades == nil {
+		profile.Upgrades = map[string]int{}
+	}
+	if profile.Achievements == nil {
+		profile.Achievements = map[string]bool{}
+	}
+	if profile.Counters == nil {
+		profile.Counters = map[string]int{}
+	}
+	return profile, nil
+}
+
+// Save writes to a temporary file first, so a crash can't leave a half written profile.
+func (p *Profile) Save() error {
+	if p.readOnly {
+		return nil
+	}
+	data, err := json.MarshalIndent(p, "", "    ")
+	if err != nil {
+		return err
+	}
+	tmp := p.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p.path)
+}
+
+func (p *Profile) IsUnlocked(ship string, cost int) bool {
+	return cost <= 0 || slices.Contains(p.UnlockedShips, ship)
+}
+
+// Spend takes the credits if there is enough of them.
+func (p *Profile) Spend(cost int) bool {
+	if p.Credits < cost {
+		return false
+	}
+	p.Credits -= cost
+	return true
+}
+
+func (p *Profile) UnlockShip(ship string, cost int) bool {
+	if p.IsUnlocked(ship, cost) || !p.Spend(cost) {
+		return false
+	}
+	p.UnlockedShips = append(p.UnlockedShips, ship)
+	p.save()
+	return true
+}
+
+func (p *Profile) BuyUpgrade(id string, cost int) bool {
+	if !p.Spend(cost) {
+		return false
+	}
+	p.Upgrades[id]++
+	p.save()
+	return true
+}
+
+// RunCredits is how many credits a run earns based on the score and kills.
+func RunCredits(score, kills int) int {
+	return score/50 + kills
+}
+
+func (p *Profile) AwardRun(score, kills int) int {
+	credits := RunCredits(score, kills)
+	p.Credits += credits
+	p.Runs++
+	p.save()
+	return credits
+}
+
+// UnlockAchievement returns false when it was already unlocked.
+func (p *Profile) UnlockAchievement(id string) bool {
+	if p.Achievements[id] {
+		return false
+	}
+	p.Achievements[id] = true
+	p.save()
+	return true
+}
+
+// SaveAudio keeps the audio settings changed in the game over the [audio] config.
+func (p *Profile) SaveAudio(a AudioSettings) {
+	a.MutedBuses = slices.Clone(a.MutedBuses)
+	p.Audio = &a
+	p.save()
+}
+
+func (p *Profile) save() {
+	if err := p.Save(); err != nil {
+		Logger(SubsystemGame).Error("failed to save profile", "path", p.path, "err", err)
+	}
+}
@@ -0,0 +1,70 @@
+package game
+
+import (
+	"errors"
+	"net/http"
+	_ "net/http/pprof" // registers the /debug/pprof handlers
+	"os"
+	"runtime/trace"
+	"sync"
+)
+
+// StartPprof serves net/http/pprof on the address of the config, opt-in since it
+// should only listen on localhost.
+func StartPprof(cfg GameConfig) {
+	if cfg.Dev.PprofAddr == "" {
+		return
+	}
+	go func() {
+		Logger(SubsystemGame).Info("pprof listening", "addr", cfg.Dev.PprofAddr)
+		if err := http.ListenAndServe(cfg.Dev.PprofAddr, nil); err != nil {
+			Logger(SubsystemGame).Error("pprof stopped", "err", err)
+		}
+	}()
+}
+
+var tracing struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// ToggleTrace starts a runtime trace written to path, or stops the running one.
+// Returns true while tracing.
+func ToggleTrace(path string) (bool, error) {
+	tracing.mu.Lock()
+	defer tracing.mu.Unlock()
+
+	if tracing.file != nil {
+		trace.Stop()
+		err := tracing.file.Close()
+		tracing.file = nil
+		Logger(SubsystemGame).Info("trace stopped", "path", path)
+		return false, err
+	}
+
+	if path == "" {
+		return false, errors.New("trace_file is not set")
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return false, err
+	}
+	if err := trace.Start(f); err != nil {
+		f.Close()
+		return false, err
+	}
+	tracing.file = f
+	Logger(SubsystemGame).Info("trace started", "path", path)
+	return true, nil
+}
+
+// StopTrace makes sure the trace file is complete when the game exits.
+func StopTrace() {
+	tracing.mu.Lock()
+	defer tracing.mu.Unlock()
+	if tracing.file != nil {
+		trace.Stop()
+		tracing.file.Close()
+		tracing.file = nil
+	}
+}
@@ -0,0 +1,149 @@
+package game
+
+import (
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+type SceneName = string
+
+const (
+	SceneMainMenu   SceneName = "main_menu" // main menu, compendium and hangar
+	SceneShipSelect SceneName = "ship_select"
+	ScenePlaying    SceneName = "playing"
+	ScenePaused     SceneName = "paused"   // overlay
+	SceneLevelUp    SceneName = "level_up" // overlay
+	SceneGameOver   SceneName = "game_over"
+	SceneConsole    SceneName = "console" // overlay
+)
+
+// Scene is a state of the game flow. Scenes are stacked, the top one gets the input
+// first and decides whether the game is halted. Overlays (pause, level up, console)
+// are pushed on top of the scene they belong to and popped when closed.
+type Scene interface {
+	GetName() SceneName
+	// Entities are added to the game before Enter and removed after Exit, they only
+	// exist while the scene is on the stack (i.e the game over report).
+	Entities() []Entity
+	Enter(gc *GameContext)
+	Exit(gc *GameContext)
+	// InputEvents returns true when the event was consumed, otherwise the entities get it.
+	InputEvents(event tcell.Event, gc *GameContext) bool
+	Halts() bool // the simulation and collisions are frozen while the scene is on top
+}
+
+// SceneStack is used from the input and the game loop, the hooks run without the lock
+// so they can push or pop other scenes.
+type SceneStack struct {
+	mu     sync.Mutex
+	scenes []Scene
+}
+
+func (s *SceneStack) Push(gc *GameContext, scene Scene) {
+	s.mu.Lock()
+	s.scenes = append(s.scenes, scene)
+	s.mu.Unlock()
+
+	Logger(SubsystemUI).Debug("scene pushed", "scene", scene.GetName())
+	gc.AddEntity(scene.Entities()...)
+	scene.Enter(gc)
+}
+
+// Pop removes the top scene when it is the named one, returns false otherwise.
+func (s *SceneStack) Pop(gc *GameContext, name SceneName) bool {
+	return s.pop(gc, func(top Scene) bool {
+		return top.GetName() == name
+	})
+}
+
+// PopScene removes the scene only when it is the top one, the scenes pushed over it
+// since (i.e a level up over the console) are left alone.
+func (s *SceneStack) PopScene(gc *GameContext, scene Scene) bool {
+	return s.pop(gc, func(top Scene) bool {
+		return top == scene
+	})
+}
+
+func (s *SceneStack) pop(gc *GameContext, match func(top Scene) bool) bool {
+	s.mu.Lock()
+	n := len(s.scenes)
+	if n == 0 || !match(s.scenes[n-1]) {
+		s.mu.Unlock()
+		return false
+	}
+	top := s.scenes[n-1]
+	s.scenes = s.scenes[:n-1]
+	s.mu.Unlock()
+
+	Logger(SubsystemUI).Debug("scene popped", "scene", top.GetName())
+	exit(gc, top)
+	return true
+}
+
+// exit runs the exit hook, then removes the entities of the scene.
+func exit(gc *GameContext, scene Scene) {
+	scene.Exit(gc)
+	for _, entity := range scene.Entities() {
+		gc.RemoveEntity(entity)
+	}
+}
+
+// Replace transitions to the scene, every scene on the stack is exited (top first).
+func (s *SceneStack) Replace(gc *GameContext, scene Scene) {
+	s.mu.Lock()
+	old := s.scenes
+	s.scenes = nil
+	s.mu.Unlock()
+
+	for i := len(old) - 1; i >= 0; i-- {
+		exit(gc, old[i])
+	}
+	s.Push(gc, scene)
+}
+
+// Reset drops the scenes without their exit hooks, used when the game restarts (all the
+// entities are removed).
+func (s *SceneStack) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scenes = nil
+}
+
+func (s *SceneStack) Top() Scene {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.scenes) == 0 {
+		return nil
+	}
+	return s.scenes[len(s.scenes)-1]
+}
+
+// Is reports whether the named scene is on top.
+func (s *SceneStack) Is(name SceneName) bool {
+	top := s.Top()
+	return top != nil && top.GetName() == name
+}
+
+// Has reports whether the named scene is anywhere on the stack.
+func (s *SceneStack) Has(name SceneName) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, scene := range s.scenes {
+		if scene.GetName() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *SceneStack) Halted() bool {
+	top := s.Top()
+	return top != nil && top.Halts()
+}
+
+// InputEvents gives the event to the top scene, returns true when it was consumed.
+func (s *SceneStack) InputEvents(event tcell.Event, gc *GameContext) bool {
+	top := s.Top()
+	return top != nil && top.InputEvents(event, gc)
+}
@@ -2,109 +2,418 @@ package game
 
 import (
 	"bytes"
+	"cmp"
+	"fmt"
 	"io"
+	"math"
 	"math/rand"
-	"sync/atomic"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/gopxl/beep/v2"
 	"github.com/gopxl/beep/v2/effects"
+	"github.com/gopxl/beep/v2/flac"
 	"github.com/gopxl/beep/v2/mp3"
 	"github.com/gopxl/beep/v2/speaker"
+	"github.com/gopxl/beep/v2/vorbis"
+	"github.com/gopxl/beep/v2/wav"
 	"github.com/omar0ali/spaceinvaders-game-cli/game/assets"
+	"github.com/omar0ali/spaceinvaders-game-cli/game/loader"
 )
 
-// to limit how many sounds playing at the same time.
-var (
-	soundsPlaying int32
-	maxSounds     int32 = 20
+const (
+	sampleRate        = beep.SampleRate(44100)
+	maxVoices         = 20 // sounds mixed at the same time
+	maxVoicesPerSound = 4  // i.e the beams of many aliens firing together
+	maxPan            = 0.8
+	maxAttenuation    = 2 // base 2, a sound across the playfield plays at a quarter of the volume
 )
 
+// Bus is a channel with its own volume, every sound is played on one.
+type Bus = string
+
+const (
+	BusSFX    Bus = "sfx"
+	BusUI     Bus = "ui"
+	BusMusic  Bus = "music"
+	BusAlerts Bus = "alerts"
+)
+
+var Buses = []Bus{BusSFX, BusUI, BusMusic, BusAlerts}
+
+// SoundEvent is what the game plays (i.e player_fire, boss_alarm), sounds.json maps every
+// event to the files it picks from at random. When all the voices are in use, a sound
+// steals the oldest voice of a lower or equal priority.
+type SoundEvent struct {
+	Files     []string `json:"files"`     // sound files or synths from sfx.json
+	Bus       Bus      `json:"bus"`       // sfx when empty
+	Volume    float64  `json:"volume"`    // relative to the bus, 1 when empty
+	Variation float64  `json:"variation"` // the volume of every play varies by up to this fraction
+	Priority  int      `json:"priority"`
+}
+
+// Emitter is where a sound comes from on the playfield. It is panned by X across the
+// width and gets quieter the further it is from the listener.
+type Emitter struct {
+	X, Y float64
+}
+
+// listener is the player, the size of the playfield scales the pan and the distance.
+type listener struct {
+	x, y          float64
+	width, height float64
+}
+
+// bus mixes the voices played on it, the volume is changed live from the settings.
+type bus struct {
+	mixer  *beep.Mixer
+	volume *effects.Volume
+}
+
 type nopCloser struct {
 	io.Reader
 }
 
 func (nopCloser) Close() error { return nil }
 
+// Sound is decoded once when the game starts, every voice streams from the buffer.
 type Sound struct {
-	Data   []byte
-	Format beep.Format
+	Buffer *beep.Buffer
+}
+
+// voice is a sound playing in the mixer, the mixer drops it once it is done.
+type voice struct {
+	name     string
+	priority int
+	streamer beep.Streamer
+	done     bool
+}
+
+func (v *voice) Stream(samples [][2]float64) (int, bool) {
+	if v.done {
+		return 0, false
+	}
+	n, ok := v.streamer.Stream(samples)
+	if n < len(samples) || !ok {
+		v.done = true
+	}
+	return n, ok
+}
+
+func (v *voice) Err() error {
+	return nil
 }
 
 type SoundSystem struct {
-	Sounds map[string]Sound
-	cfg    GameConfig
+	Sounds   map[string]Sound
+	Events   map[string]SoundEvent   // from sounds.json
+	Synths   map[string]*SynthDesign // from sfx.json, played by name like the sounds
+	Settings AudioSettings           // starts from the [audio] config, changed from the settings menu
+	OnChange func(AudioSettings)     // called after the settings changed, i.e to save them
+	cfg      GameConfig
+	master   *effects.Volume
+	buses    map[Bus]*bus
+	voices   []*voice // guarded by the speaker lock
+
+	musicBuffers map[string]*beep.Buffer
+	music        *musicVoice // the theme fading in or playing, guarded by the speaker lock
+	intensity    int         // enemies on screen, picks the music layers
+	listener     listener    // guarded by the speaker lock
 }
 
 func InitSoundSystem(cfg GameConfig) *SoundSystem {
 	if !cfg.Dev.Sounds {
-		return &SoundSystem{}
+		return &SoundSystem{Settings: cfg.Audio}
 	}
 	var sounds = map[string]Sound{}
 	entries, _ := assets.SoundFS.ReadDir("sounds")
 	for _, e := range entries {
 		name := e.Name()
 		data, _ := assets.SoundFS.ReadFile("sounds/" + name)
-		r := nopCloser{bytes.NewBuffer(data)}
+		buffer, err := decodeSound(name, data)
+		if err != nil {
+			Logger(SubsystemSound).Error("failed to decode", "name", name, "err", err)
+			continue
+		}
+		Logger(SubsystemSound).Info("load sound", "name", name, "samples", buffer.Len())
+		sounds[name] = Sound{Buffer: buffer}
+	}
+	synths := map[string]*SynthDesign{}
+	designs, err := loader.LoadListOfAssets[SynthDesign]("sfx.json")
+	if err != nil {
+		Logger(SubsystemSound).Error("failed to load sfx.json", "err", err)
+	}
+	for i := range designs {
+		synths[designs[i].Name] = &designs[i]
+	}
+	events, err := loader.LoadAsset[map[string]SoundEvent]("sounds.json")
+	if err != nil {
+		Logger(SubsystemSound).Error("failed to load sounds.json", "err", err)
+	}
 
-		Log(Info, "LOAD SOUND: %s", name)
-		streamer, format, _ := mp3.Decode(r)
-		streamer.Close()
-		sounds[name] = Sound{Data: data, Format: format}
+	s := &SoundSystem{
+		Sounds:       sounds,
+		Events:       events,
+		Synths:       synths,
+		Settings:     cfg.Audio,
+		cfg:          cfg,
+		buses:        map[Bus]*bus{},
+		musicBuffers: loadMusic(cfg.Mods.Path),
 	}
 
-	// prepare speaker only once.
-	sampleRate := beep.SampleRate(44100) // usign mp3
+	// every bus is mixed into the master volume
+	master := &beep.Mixer{}
+	for _, name := range Buses {
+		mixer := &beep.Mixer{}
+		b := &bus{mixer: mixer, volume: &effects.Volume{Streamer: mixer, Base: 2}}
+		s.buses[name] = b
+		master.Add(b.volume)
+	}
+	s.master = &effects.Volume{Streamer: master, Base: 2}
+	s.applySettings()
+
+	// prepare speaker only once
 	speaker.Init(sampleRate, sampleRate.N(time.Second/10))
+	speaker.Play(s.master)
+	return s
+}
+
+// decodeSound decodes the whole file (mp3, wav, ogg or flac by extension) into a buffer
+// at the sample rate of the speaker.
+func decodeSound(name string, data []byte) (*beep.Buffer, error) {
+	var (
+		streamer beep.StreamSeekCloser
+		format   beep.Format
+		err      error
+	)
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".mp3":
+		streamer, format, err = mp3.Decode(nopCloser{bytes.NewReader(data)})
+	case ".wav":
+		streamer, format, err = wav.Decode(bytes.NewReader(data))
+	case ".ogg":
+		streamer, format, err = vorbis.Decode(nopCloser{bytes.NewReader(data)})
+	case ".flac":
+		streamer, format, err = flac.Decode(bytes.NewReader(data))
+	default:
+		return nil, fmt.Errorf("unsupported sound format %q", filepath.Ext(name))
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer streamer.Close()
 
-	return &SoundSystem{
-		Sounds: sounds,
-		cfg:    cfg,
+	var s beep.Streamer = streamer
+	if format.SampleRate != sampleRate {
+		s = beep.Resample(4, format.SampleRate, sampleRate, streamer)
 	}
+	buffer := beep.NewBuffer(beep.Format{SampleRate: sampleRate, NumChannels: 2, Precision: 2})
+	buffer.Append(s)
+	return buffer, nil
 }
 
-func (s *SoundSystem) PlayRandom(names []string, vol float64) {
-	if len(names) == 0 {
-		return
+// Voices returns how many sounds are playing and how many can play at once.
+func (s *SoundSystem) Voices() (playing, limit int) {
+	if s.master == nil {
+		return 0, maxVoices
 	}
-	idx := rand.Intn(len(names))
-	s.PlaySound(names[idx], vol)
+	speaker.Lock()
+	defer speaker.Unlock()
+	s.prune()
+	return len(s.voices), maxVoices
 }
 
-func (s *SoundSystem) PlaySound(name string, vol float64) {
+// PlaySound plays a sound event, centered unless it is played from an emitter. Names
+// missing from sounds.json are played as a file or a synth on the sfx bus, i.e the
+// sounds set in the designs.
+func (s *SoundSystem) PlaySound(name string, at ...Emitter) {
 	if !s.cfg.Dev.Sounds {
 		return
 	}
-	sound, ok := s.Sounds[name]
+	event, ok := s.Events[name]
 	if !ok {
-		Log(Error, "Failed to locate the file. %s ", name)
+		event = SoundEvent{Files: []string{name}}
+	}
+	if len(event.Files) == 0 {
+		return
 	}
+	file := event.Files[rand.Intn(len(event.Files))]
 
-	if atomic.LoadInt32(&soundsPlaying) >= maxSounds {
-		Log(Debug, "Skipping sound: too many playing")
+	var streamer beep.Streamer
+	if sound, ok := s.Sounds[file]; ok {
+		streamer = sound.Buffer.Streamer(0, sound.Buffer.Len())
+	} else if synth, ok := s.Synths[file]; ok {
+		streamer = newSynthVoice(synth)
+	} else {
+		Logger(SubsystemSound).Error("failed to locate the file", "name", name, "file", file)
+		return
+	}
+	busName := cmp.Or(event.Bus, BusSFX)
+	b, ok := s.buses[busName]
+	if !ok {
+		Logger(SubsystemSound).Error("unknown bus", "bus", busName, "name", name)
 		return
 	}
 
-	Log(Debug, "Sounds Playing: %s", name)
+	gain := cmp.Or(event.Volume, 1) * (1 + (rand.Float64()*2-1)*event.Variation)
+	volume := &effects.Volume{
+		Streamer: streamer,
+		Base:     2,
+		Volume:   math.Log2(max(gain, 0.01)),
+	}
+	pan := &effects.Pan{Streamer: volume}
+	v := &voice{
+		name:     name,
+		priority: event.Priority,
+		streamer: pan,
+	}
 
-	go func() {
-		atomic.AddInt32(&soundsPlaying, 1)
-		r := nopCloser{bytes.NewReader(sound.Data)}
-		streamer, _, err := mp3.Decode(r)
-		if err != nil {
-			Log(Error, "Failed to decode %s", err)
-			return
+	speaker.Lock()
+	defer speaker.Unlock()
+	if len(at) > 0 {
+		var attenuation float64
+		pan.Pan, attenuation = s.listener.spatialize(at[0])
+		volume.Volume -= attenuation
+	}
+	s.prune()
+	if !s.allocate(v) {
+		Logger(SubsystemSound).Debug("skipping sound, too many playing", "name", name)
+		return
+	}
+	Logger(SubsystemSound).Debug("sound playing", "name", name)
+	s.voices = append(s.voices, v)
+	b.mixer.Add(v)
+}
+
+// SetListener moves the listener, width and height are the size of the playfield.
+func (s *SoundSystem) SetListener(x, y float64, width, height int) {
+	if s.master == nil {
+		return
+	}
+	speaker.Lock()
+	defer speaker.Unlock()
+	s.listener = listener{x: x, y: y, width: float64(width), height: float64(height)}
+}
+
+// spatialize returns the pan of the emitter by its position across the playfield, and
+// how much quieter (base 2) it is by its distance from the listener.
+func (l listener) spatialize(e Emitter) (pan, attenuation float64) {
+	if l.width <= 0 || l.height <= 0 {
+		return 0, 0
+	}
+	pan = min(max(e.X/l.width*2-1, -1), 1) * maxPan
+	distance := math.Hypot((e.X-l.x)/l.width, (e.Y-l.y)/l.height) / math.Sqrt2
+	return pan, min(distance, 1) * maxAttenuation
+}
+
+// SetVolume changes the volume (0 to 1) of a bus, or of the master when bus is empty.
+func (s *SoundSystem) SetVolume(bus Bus, volume float64) {
+	volume = min(max(volume, 0), 1)
+	if bus == "" {
+		s.Settings.Master = volume
+	} else {
+		s.Settings.setVolume(bus, volume)
+	}
+	s.update()
+	s.changed()
+}
+
+// SetMuted mutes a bus, or everything when bus is empty.
+func (s *SoundSystem) SetMuted(bus Bus, muted bool) {
+	if bus == "" {
+		s.Settings.Muted = muted
+	} else {
+		s.Settings.MutedBuses = slices.DeleteFunc(s.Settings.MutedBuses, func(b string) bool { return b == bus })
+		if muted {
+			s.Settings.MutedBuses = append(s.Settings.MutedBuses, bus)
+		}
+	}
+	s.update()
+	s.changed()
+}
+
+// ToggleMute mutes or unmutes everything, returns true when muted.
+func (s *SoundSystem) ToggleMute() bool {
+	s.SetMuted("", !s.Settings.Muted)
+	return s.Settings.Muted
+}
+
+// UseSettings replaces the settings, i.e with the ones saved in the profile.
+func (s *SoundSystem) UseSettings(a AudioSettings) {
+	s.Settings = a
+	s.Settings.MutedBuses = slices.Clone(a.MutedBuses)
+	s.update()
+}
+
+func (s *SoundSystem) changed() {
+	if s.OnChange != nil {
+		s.OnChange(s.Settings)
+	}
+}
+
+func (s *SoundSystem) update() {
+	if s.master == nil {
+		return
+	}
+	speaker.Lock()
+	defer speaker.Unlock()
+	s.applySettings()
+}
+
+// applySettings sets the volumes of the mixers, the linear volumes are converted to
+// the base 2 volume of beep.
+func (s *SoundSystem) applySettings() {
+	apply := func(v *effects.Volume, volume float64, muted bool) {
+		v.Silent = muted || volume <= 0
+		if !v.Silent {
+			v.Volume = math.Log2(volume)
 		}
+	}
+	apply(s.master, s.Settings.Master, s.Settings.Muted)
+	for name, b := range s.buses {
+		apply(b.volume, s.Settings.Volume(name), s.Settings.IsMuted(name))
+	}
+}
 
-		v := &effects.Volume{
-			Streamer: streamer,
-			Base:     2,
-			Volume:   vol,
-			Silent:   false,
+// allocate makes room for the voice, stealing the oldest one of the same sound when it
+// reached its limit, or the oldest of a lower or equal priority when all are in use.
+func (s *SoundSystem) allocate(v *voice) bool {
+	same := 0
+	for _, playing := range s.voices {
+		if playing.name == v.name {
+			same++
 		}
-		speaker.Play(beep.Seq(v, beep.Callback(func() {
-			streamer.Close()
-			atomic.AddInt32(&soundsPlaying, -1)
-		})))
-	}()
+	}
+	steal := func(match func(*voice) bool) bool {
+		for i, playing := range s.voices { // oldest first
+			if match(playing) {
+				playing.done = true
+				s.voices = append(s.voices[:i], s.voices[i+1:]...)
+				return true
+			}
+		}
+		return false
+	}
+
+	if same >= maxVoicesPerSound {
+		return steal(func(playing *voice) bool { return playing.name == v.name })
+	}
+	if len(s.voices) >= maxVoices {
+		return steal(func(playing *voice) bool { return playing.priority <= v.priority })
+	}
+	return true
+}
+
+// prune drops the voices that finished playing.
+func (s *SoundSystem) prune() {
+	voices := s.voices[:0]
+	for _, v := range s.voices {
+		if !v.done {
+			voices = append(voices, v)
+		}
+	}
+	clear(s.voices[len(voices):])
+	s.voices = voices
 }
@@ -0,0 +1,111 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/gopxl/beep/v2"
+)
+
+type Wave = string
+
+const (
+	WaveSquare   Wave = "square"
+	WaveTriangle Wave = "triangle"
+	WaveNoise    Wave = "noise"
+)
+
+// SynthDesign is a sound generated when played instead of read from a file (like sfxr),
+// listed in sfx.json and played by its name like any other sound.
+type SynthDesign struct {
+	Name      string  `json:"name"`
+	Wave      Wave    `json:"wave"`
+	Frequency float64 `json:"frequency"` // Hz, for noise how often a new random value is picked
+	Sweep     float64 `json:"sweep"`     // Hz per second, negative sweeps down
+	Duty      float64 `json:"duty"`      // square only, 0.5 when empty
+	// envelope in seconds, the sound holds at the sustain level (0 to 1) between the
+	// decay and the release
+	Attack  float64 `json:"attack"`
+	Decay   float64 `json:"decay"`
+	Sustain float64 `json:"sustain"`
+	Hold    float64 `json:"hold"`
+	Release float64 `json:"release"`
+	Volume  float64 `json:"volume"` // 0 to 1
+	// PitchJitter varies the frequency of every play by up to this fraction, i.e 0.05
+	// is 5% so repeated shots don't sound the same
+	PitchJitter float64 `json:"pitch_jitter"`
+}
+
+func (d *SynthDesign) duration() float64 {
+	return d.Attack + d.Decay + d.Hold + d.Release
+}
+
+func (d *SynthDesign) envelope(t float64) float64 {
+	switch {
+	case t < d.Attack:
+		return t / d.Attack
+	case t < d.Attack+d.Decay:
+		return 1 - (1-d.Sustain)*(t-d.Attack)/d.Decay
+	case t < d.Attack+d.Decay+d.Hold:
+		return d.Sustain
+	case t < d.duration():
+		return d.Sustain * (1 - (t-d.Attack-d.Decay-d.Hold)/d.Release)
+	}
+	return 0
+}
+
+// synthVoice generates the samples of a SynthDesign until the end of its envelope.
+type synthVoice struct {
+	design    *SynthDesign
+	frequency float64
+	phase     float64 // 0 to 1 within the period
+	noise     float64
+	t         float64
+}
+
+func newSynthVoice(d *SynthDesign) beep.Streamer {
+	jitter := 1 + (rand.Float64()*2-1)*d.PitchJitter
+	return &synthVoice{design: d, frequency: d.Frequency * jitter, noise: rand.Float64()*2 - 1}
+}
+
+func (s *synthVoice) Stream(samples [][2]float64) (int, bool) {
+	d := s.design
+	dt := 1 / float64(sampleRate)
+	for i := range samples {
+		if s.t >= d.duration() {
+			return i, i > 0
+		}
+		v := s.oscillator() * d.envelope(s.t) * d.Volume
+		samples[i] = [2]float64{v, v}
+
+		s.phase += s.frequency * dt
+		if s.phase >= 1 {
+			s.phase -= math.Floor(s.phase)
+			s.noise = rand.Float64()*2 - 1
+		}
+		s.frequency = max(s.frequency+d.Sweep*dt, 20)
+		s.t += dt
+	}
+	return len(samples), true
+}
+
+func (s *synthVoice) oscillator() float64 {
+	switch s.design.Wave {
+	case WaveTriangle:
+		return 4*math.Abs(s.phase-0.5) - 1
+	case WaveNoise:
+		return s.noise
+	}
+	duty := s.design.Duty
+	if duty <= 0 || duty >= 1 {
+		duty = 0.5
+	}
+	if s.phase < duty {
+		return 1
+	}
+	return -1
+}
+
+func (s *synthVoice) Err() error {
+	return nil
+}
@@ -3,12 +3,11 @@ package main
 
 import (
 	"fmt"
-	"log"
+	"slices"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/omar0ali/spaceinvaders-game-cli/base"
 	"github.com/omar0ali/spaceinvaders-game-cli/entities"
-	"github.com/omar0ali/spaceinvaders-game-cli/entities/ui"
 	"github.com/omar0ali/spaceinvaders-game-cli/game"
 )
 
@@ -17,80 +16,124 @@ func main() {
 
 	// setup logs
 	if cfg.Dev.Debug {
-		logFile := game.SetupLogs()
+		logFile := game.SetupLogs(cfg)
 		defer logFile.Close()
 	}
 
 	exit := make(chan struct{})
 
 	// ------------------------------- Setup ------------------------------------
-	screen := base.InitScreen(base.EnableMouse)
+	opts := []base.OptsFunc{
+		base.EnableMouse,
+		base.Playfield(cfg.Screen.AspectRatio, cfg.Screen.MinWidth, cfg.Screen.MinHeight),
+	}
+	if cfg.Render.LowBandwidth {
+		opts = append(opts, base.LowBandwidth(cfg.Render.Bandwidth))
+	}
+	screen := base.InitScreen(opts...)
 	screen.SetTitle("Space Invader Game")
 
 	sounds := game.InitSoundSystem(cfg)
+	game.StartPprof(cfg)
+	defer game.StopTrace()
 
 	// ------------------------------------- Objects ----------------------------------
+	profile := game.LoadProfile(cfg)
+	// the settings changed in the game are saved in the profile over the [audio] config
+	if profile.Audio != nil {
+		sounds.UseSettings(*profile.Audio)
+	}
+	sounds.OnChange = profile.SaveAudio
+
 	gameContext := game.GameContext{
-		Screen: screen,
-		Sounds: sounds,
+		Screen:    screen,
+		Sounds:    sounds,
+		Profile:   profile,
+		TimeScale: 1,
+		Frame:     game.NewFrameSpans(cfg),
 	}
 	// ---------------------------------- entities --------------------------------------
 
-	log.Println("Game running...")
+	game.Logger(game.SubsystemGame).Info("game running")
 
 	entities.StartGame(&gameContext, cfg, exit)
 
 	// ----------------------------------------- window ------------------------------------
 	base.InputEvent(exit,
 		func(event tcell.Event) {
+			if base.TooSmall() {
+				return
+			}
 			switch ev := event.(type) {
 			case *tcell.EventKey:
 				if ev.Key() == tcell.KeyCtrlR {
 					entities.RestartGame(&gameContext, cfg, exit)
 				}
 			}
-			for _, entity := range gameContext.GetEntities() {
+			// the top scene gets the input first, i.e the open console takes all of it
+			if gameContext.Scenes.InputEvents(event, &gameContext) {
+				return
+			}
+			// a copy, the scenes add and remove their entities while handling the input
+			for _, entity := range slices.Clone(gameContext.GetEntities()) {
 				entity.InputEvents(event, &gameContext)
 			}
 		},
 	)
 
+	frame := gameContext.Frame
+	width, height := base.GetSize()
+
 	base.Update(exit,
 		func(delta float64) {
+			frame.Begin()
+			defer frame.End()
+
 			// update game
+			base.SetLayer(game.LayerHUD)
 			if cfg.Dev.FPSCounter {
 				// fps
 				for i, r := range []rune(fmt.Sprintf("FPS: %.2f", (1 / delta))) {
 					base.SetContent(i, 0, r)
 				}
 			}
-			// only let ui to be displayed
-			if gameContext.Halt {
-				if star, ok := gameContext.FindEntity("star").(*entities.StarProducer); ok {
-					star.Update(&gameContext, delta)
-					star.Draw(&gameContext)
-				}
-				if ui, ok := gameContext.FindEntity("ui").(*entities.UI); ok {
-					ui.Update(&gameContext, delta)
-					ui.Draw(&gameContext)
-				}
-				if layout, ok := gameContext.FindEntity("layout").(*ui.UISystem); ok {
-					layout.Update(&gameContext, delta)
-					layout.Draw(&gameContext)
-				}
-				// draw only (to display all objects when game is paused)
-				for _, entity := range gameContext.GetEntities() {
-					entity.Draw(&gameContext)
+			delta *= gameContext.TimeScale
+
+			if base.TooSmall() {
+				base.SetLayer(game.LayerMenu)
+				entities.DrawResizeWarning()
+				return
+			}
+			if w, h := base.GetSize(); w != width || h != height {
+				game.Publish(&gameContext, game.Resized{Width: w, Height: h, PrevWidth: width, PrevHeight: height})
+				width, height = w, h
+			}
+
+			// while halted (menus, pause) or frozen only the input and render phases are
+			// updated, everything is still drawn
+			frozen := gameContext.FreezeTime > 0
+			gameContext.FreezeTime = max(gameContext.FreezeTime-delta, 0)
+			for _, entity := range gameContext.EntitiesByPhase() {
+				if (frozen || gameContext.Scenes.Halted()) && !entity.GetPhase().RunsHalted() {
+					continue
 				}
-			} else { // update everything
-				for _, entity := range gameContext.GetEntities() {
-					entity.Draw(&gameContext)
+				frame.Span(entity.GetType()+".update", func() {
 					entity.Update(&gameContext, delta)
-				}
+				})
 			}
+			for _, entity := range gameContext.EntitiesByLayer() {
+				frame.Span(entity.GetType()+".draw", func() {
+					base.SetLayer(entity.GetLayer())
+					entity.Draw(&gameContext)
+				})
+			}
+			frame.Span("events", gameContext.Events.Flush)
 		},
 	)
 
-	// exit
+	// exit, the achievement counters are only saved with the profile from time to time
 	<-exit
+	if err := gameContext.Profile.Save(); err != nil {
+		game.Logger(game.SubsystemGame).Error("failed to save profile", "err", err)
+	}
 }